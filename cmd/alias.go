@@ -12,27 +12,25 @@ var aliasCmd = &cobra.Command{
 	Use:   "alias [project] [alias]",
 	Short: "Set an alias for a project",
 	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		projectName := args[0]
 		alias := args[1]
 
 		cfg, err := config.Load()
 		if err != nil {
-			utils.PrintError("Failed to load config: " + err.Error())
-			return
+			return configError("load", err)
 		}
 
 		project, exists := cfg.Projects[projectName]
 		if !exists {
 			projectPath := config.FindProjectPath(cfg, projectName)
 			if projectPath == "" {
-				utils.PrintError(fmt.Sprintf("Project '%s' not found", projectName))
-				return
+				return notFoundError("Project '%s' not found", projectName)
 			}
 			project = config.Project{
 				Name:   projectName,
 				Path:   projectPath,
-				Status: "active",
+				Status: config.InitialStatus(cfg),
 			}
 		}
 
@@ -41,11 +39,11 @@ var aliasCmd = &cobra.Command{
 		cfg.Projects[alias] = project
 
 		if err := config.Save(cfg); err != nil {
-			utils.PrintError("Failed to save config: " + err.Error())
-			return
+			return configError("save", err)
 		}
 
 		utils.PrintSuccess(fmt.Sprintf("Alias '%s' set for project '%s'", alias, projectName))
+		return nil
 	},
 }
 
@@ -0,0 +1,117 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/archive"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/hooks"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// archivedStatus is the status archive moves projects to. It has to be one
+// of the configured statuses and reachable from the project's status.
+const archivedStatus = "archived"
+
+var compactArchive bool
+
+var archiveCmd = &cobra.Command{
+	Use:   "archive [project]",
+	Short: "Archive a project, optionally packing it into a tarball",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, exists := cfg.Projects[projectName]
+		if !exists {
+			projectPath := config.FindProjectPath(cfg, projectName)
+			if projectPath == "" {
+				return notFoundError("Project '%s' not found", projectName)
+			}
+			project = config.Project{
+				Name: projectName,
+				Path: projectPath,
+			}
+		}
+
+		if project.Archive != "" {
+			return failedError("Project '%s' is already compacted at %s", projectName, project.Archive)
+		}
+
+		target, ok := config.LookupStatus(cfg, archivedStatus)
+		if !ok {
+			return invalidError("No '%s' status is configured. Valid: %s", archivedStatus, strings.Join(config.StatusNames(cfg), ", "))
+		}
+		if err := config.CheckTransition(cfg, project.Status, target.Name); err != nil {
+			return invalidError("Project '%s': %v", projectName, err)
+		}
+
+		ev := hooks.Event{
+			Name:           hooks.EventStatus,
+			Project:        project.Name,
+			Path:           project.Path,
+			Workspace:      config.WorkspaceOf(cfg, project),
+			Status:         target.Name,
+			PreviousStatus: project.Status,
+		}
+		if err := hooks.Pre(cfg, ev); err != nil {
+			return vetoedError(err)
+		}
+
+		config.ChangeStatus(&project, target.Name, "")
+
+		if compactArchive {
+			archiveRoot, err := config.GetArchiveRoot(cfg)
+			if err != nil {
+				return failedError("Failed to resolve archive root: %w", err)
+			}
+
+			archivePath := filepath.Join(archiveRoot, archive.ArchiveName(project.Name))
+			size, err := archive.Compact(project.Path, archivePath)
+			if err != nil {
+				return failedError("Failed to compact project: %w", err)
+			}
+
+			utils.PrintInfo(fmt.Sprintf("Packed into %s (%s)", archivePath, utils.FormatSize(size)))
+
+			// Record the tarball before the directory goes, so that restore can
+			// always find it.
+			project.Archive = archivePath
+			config.UpdateProject(cfg, project)
+			if err := config.Save(cfg); err != nil {
+				os.Remove(archivePath)
+				return configError("save", err)
+			}
+
+			if err := os.RemoveAll(project.Path); err != nil {
+				return failedError("Failed to remove project directory: %w", err)
+			}
+		} else {
+			config.UpdateProject(cfg, project)
+			if err := config.Save(cfg); err != nil {
+				return configError("save", err)
+			}
+		}
+
+		if err := hooks.Post(cfg, ev); err != nil {
+			utils.PrintWarning(err.Error())
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Project '%s' archived", projectName))
+		return nil
+	},
+}
+
+func init() {
+	archiveCmd.Flags().BoolVarP(&compactArchive, "compact", "c", false, "Pack the project directory into a compressed tarball")
+	rootCmd.AddCommand(archiveCmd)
+}
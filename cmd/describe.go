@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var describeClear bool
+
+var describeCmd = &cobra.Command{
+	Use:   "describe [project] [description...]",
+	Short: "Show or set the one-line description of a project",
+	Example: `  orbit describe myproject
+  orbit describe myproject "CLI for keeping side projects in orbit"
+  orbit describe myproject --clear`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := lookupProject(cfg, args[0])
+		if err != nil {
+			return err
+		}
+
+		description := strings.Join(strings.Fields(strings.Join(args[1:], " ")), " ")
+		if description == "" && !describeClear {
+			if project.Description == "" {
+				utils.PrintInfo(fmt.Sprintf("Project '%s' has no description", project.Name))
+				return nil
+			}
+			fmt.Println(project.Description)
+			return nil
+		}
+
+		project.Description = description
+		config.UpdateProject(cfg, project)
+
+		if err := config.Save(cfg); err != nil {
+			return configError("save", err)
+		}
+
+		if description == "" {
+			utils.PrintSuccess(fmt.Sprintf("Description of '%s' cleared", project.Name))
+			return nil
+		}
+		utils.PrintSuccess(fmt.Sprintf("Description of '%s' set", project.Name))
+		return nil
+	},
+}
+
+func init() {
+	describeCmd.Flags().BoolVar(&describeClear, "clear", false, "Remove the description")
+	rootCmd.AddCommand(describeCmd)
+}
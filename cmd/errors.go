@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"syscall"
+
+	"github.com/henrynguci/orbit/internal/utils"
+)
+
+const (
+	exitGeneral  = 1
+	exitUsage    = 2
+	exitNotFound = 3
+	exitInvalid  = 4
+	exitConfig   = 5
+	exitVetoed   = 6
+)
+
+type cmdError struct {
+	kind string
+	code int
+	err  error
+}
+
+func (e *cmdError) Error() string {
+	return e.err.Error()
+}
+
+func (e *cmdError) Unwrap() error {
+	return e.err
+}
+
+func notFoundError(format string, a ...any) error {
+	return &cmdError{kind: "not_found", code: exitNotFound, err: fmt.Errorf(format, a...)}
+}
+
+func invalidError(format string, a ...any) error {
+	return &cmdError{kind: "invalid", code: exitInvalid, err: fmt.Errorf(format, a...)}
+}
+
+func configError(action string, err error) error {
+	return &cmdError{kind: "config", code: exitConfig, err: fmt.Errorf("Failed to %s config: %w", action, err)}
+}
+
+func vetoedError(err error) error {
+	return &cmdError{kind: "vetoed", code: exitVetoed, err: err}
+}
+
+func failedError(format string, a ...any) error {
+	return &cmdError{kind: "failed", code: exitGeneral, err: fmt.Errorf(format, a...)}
+}
+
+// childExitCode is the exit code to pass on for a child process. A child
+// killed by a signal has no exit code, so it gets 128 plus the signal number,
+// as shells report it.
+func childExitCode(exitErr *exec.ExitError) int {
+	if status, ok := exitErr.Sys().(syscall.WaitStatus); ok && status.Signaled() {
+		return 128 + int(status.Signal())
+	}
+	if code := exitErr.ExitCode(); code >= 0 {
+		return code
+	}
+	return exitGeneral
+}
+
+// silentError exits with code without reporting anything, for commands
+// whose exit code is the whole answer.
+func silentError(code int) error {
+	return &cmdError{kind: "silent", code: code, err: errors.New("")}
+}
+
+func reportError(err error) int {
+	var ce *cmdError
+	if !errors.As(err, &ce) {
+		// Anything not raised by a command comes from cobra's argument and flag parsing.
+		ce = &cmdError{kind: "usage", code: exitUsage, err: err}
+	}
+	if ce.kind == "silent" {
+		return ce.code
+	}
+
+	if jsonErrors {
+		data, _ := json.Marshal(map[string]any{
+			"error": ce.Error(),
+			"kind":  ce.kind,
+			"code":  ce.code,
+		})
+		fmt.Fprintln(os.Stderr, string(data))
+		return ce.code
+	}
+
+	if !quietOutput {
+		utils.PrintError(ce.Error())
+		if ce.kind == "usage" {
+			fmt.Fprintln(os.Stderr, utils.MutedStyle.Render("Run 'orbit --help' for usage."))
+		}
+	}
+
+	return ce.code
+}
@@ -0,0 +1,225 @@
+package cmd
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	execWorkspace string
+	execStatus    string
+	execTag       string
+	execSearch    string
+	execJobs      int
+)
+
+type execResult struct {
+	project  config.Project
+	output   []byte
+	err      error
+	skipped  bool
+	duration time.Duration
+}
+
+var execCmd = &cobra.Command{
+	Use:   "exec [flags] -- <command>",
+	Short: "Run a shell command in every matching project's repo",
+	Example: `  orbit exec -- git gc
+  orbit exec --status active -j 4 -- go mod tidy
+  orbit exec --workspace work --tag node -- npm audit
+  orbit exec -- sh -c 'git log -1 && git status --short'`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		command := shellCommand(args)
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		projects, err := config.SelectProjects(cfg, config.Selector{
+			Workspace: execWorkspace,
+			Status:    execStatus,
+			Tag:       execTag,
+			Search:    execSearch,
+		})
+		if err != nil {
+			return invalidError("%w", err)
+		}
+		if len(projects) == 0 {
+			return notFoundError("No projects match the given selectors")
+		}
+
+		jobs := execJobs
+		if jobs <= 0 {
+			jobs = cfg.ExecConcurrency
+		}
+		if jobs <= 0 {
+			jobs = runtime.NumCPU()
+		}
+
+		utils.PrintInfo(fmt.Sprintf("Running '%s' in %d project(s), %d at a time", command, len(projects), jobs))
+
+		results := make([]execResult, len(projects))
+		sem := make(chan struct{}, jobs)
+		var wg sync.WaitGroup
+		var printMu sync.Mutex
+
+		for i, p := range projects {
+			wg.Add(1)
+			go func(i int, p config.Project) {
+				defer wg.Done()
+				sem <- struct{}{}
+				defer func() { <-sem }()
+
+				results[i] = runInProject(p, command)
+
+				printMu.Lock()
+				printExecResult(i, results[i])
+				printMu.Unlock()
+			}(i, p)
+		}
+		wg.Wait()
+
+		failed := printExecSummary(results)
+		if failed > 0 {
+			return failedError("%d of %d project(s) failed", failed, len(results))
+		}
+		return nil
+	},
+}
+
+// shellCommand builds the command line for the shell. A single argument is
+// taken as a shell command as it is; several are quoted one by one so that
+// they reach the command with their boundaries intact.
+func shellCommand(args []string) string {
+	if len(args) == 1 {
+		return args[0]
+	}
+	quoted := make([]string, len(args))
+	for i, arg := range args {
+		quoted[i] = shellQuote(arg)
+	}
+	return strings.Join(quoted, " ")
+}
+
+// shellQuote quotes s for a POSIX shell, leaving plain words alone.
+func shellQuote(s string) string {
+	if s != "" && strings.Trim(s, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_./=:,+@%") == "" {
+		return s
+	}
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
+
+// runInProject runs command in the repo/ of a project. Unlike tasks, it does
+// not load secret/.env: the command is the same for every project and should
+// not see their secrets.
+func runInProject(p config.Project, command string) execResult {
+	repo := filepath.Join(p.Path, "repo")
+	if info, err := os.Stat(repo); err != nil || !info.IsDir() {
+		return execResult{project: p, skipped: true}
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	var out bytes.Buffer
+	c := exec.Command(shell, "-c", command)
+	c.Dir = repo
+	c.Env = os.Environ()
+	c.Stdout = &out
+	c.Stderr = &out
+
+	start := time.Now()
+	err := c.Run()
+	return execResult{project: p, output: out.Bytes(), err: err, duration: time.Since(start)}
+}
+
+// execLabelColors cycles through the theme so output from neighbouring
+// projects is easy to tell apart.
+func execLabelColors() []lipgloss.TerminalColor {
+	return []lipgloss.TerminalColor{
+		utils.PrimaryColor,
+		utils.SecondaryColor,
+		utils.InfoColor,
+		utils.WarningColor,
+	}
+}
+
+func printExecResult(i int, r execResult) {
+	colors := execLabelColors()
+	label := lipgloss.NewStyle().
+		Foreground(colors[i%len(colors)]).
+		Bold(true).
+		Render("▌ " + r.project.Name)
+
+	var status string
+	switch {
+	case r.skipped:
+		status = utils.MutedStyle.Render("skipped (no repo/)")
+	case r.err != nil:
+		status = utils.ErrorStyle.Render(fmt.Sprintf("✗ %v", r.err))
+	default:
+		status = utils.SuccessStyle.Render("✓ ok")
+	}
+
+	if !r.skipped {
+		status += " " + utils.MutedStyle.Render(r.duration.Round(time.Millisecond).String())
+	}
+
+	fmt.Printf("\n%s  %s\n", label, status)
+	if output := strings.TrimRight(string(r.output), "\n"); output != "" {
+		fmt.Println(output)
+	}
+}
+
+func printExecSummary(results []execResult) int {
+	passed, failed, skipped := 0, 0, 0
+	var failedNames []string
+	for _, r := range results {
+		switch {
+		case r.skipped:
+			skipped++
+		case r.err != nil:
+			failed++
+			failedNames = append(failedNames, r.project.Name)
+		default:
+			passed++
+		}
+	}
+
+	utils.PrintDetail("")
+	utils.PrintDetail(fmt.Sprintf("%s  %s  %s",
+		utils.SuccessStyle.Render(fmt.Sprintf("%d passed", passed)),
+		utils.ErrorStyle.Render(fmt.Sprintf("%d failed", failed)),
+		utils.MutedStyle.Render(fmt.Sprintf("%d skipped", skipped)),
+	))
+	if len(failedNames) > 0 {
+		utils.PrintDetail(utils.ErrorStyle.Render("Failed: " + strings.Join(failedNames, ", ")))
+	}
+
+	return failed
+}
+
+func init() {
+	execCmd.Flags().StringVarP(&execWorkspace, "workspace", "w", "", "Only projects in this workspace (name or path)")
+	execCmd.Flags().StringVarP(&execStatus, "status", "s", "", "Only projects with this status")
+	execCmd.Flags().StringVarP(&execTag, "tag", "t", "", "Only projects with this tag")
+	execCmd.Flags().StringVar(&execSearch, "search", "", "Only projects with this text in their name, alias, description or tags")
+	execCmd.Flags().IntVarP(&execJobs, "jobs", "j", 0, "Maximum number of projects to run concurrently (default: exec_concurrency or CPU count)")
+	rootCmd.AddCommand(execCmd)
+}
@@ -0,0 +1,47 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/spf13/cobra"
+)
+
+var hereCmd = &cobra.Command{
+	Use:   "here",
+	Short: "Show the project and workspace of the working directory",
+	Long: `Show the registered project containing the working directory, found by
+walking up to the nearest project path, and its workspace. This is the
+project "status", "set", "info" and "history" use when no project is named.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, ok := config.CurrentProject(cfg)
+		if !ok {
+			cwd, _ := os.Getwd()
+			return notFoundError("No project contains %s", cwd)
+		}
+
+		workspace := config.WorkspaceOf(cfg, project)
+		if workspace == "" {
+			workspace = "none"
+		}
+
+		fmt.Printf("\n")
+		fmt.Printf("  📁 Project:   %s\n", project.Name)
+		fmt.Printf("  🗂️  Workspace: %s\n", workspace)
+		fmt.Printf("  📊 Status:    %s\n", formatStatus(cfg, config.StatusOf(project)))
+		fmt.Printf("  📍 Path:      %s\n", project.Path)
+		fmt.Printf("\n")
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(hereCmd)
+}
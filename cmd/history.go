@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var historyCmd = &cobra.Command{
+	Use:   "history [project]",
+	Short: "Show the status history of a project",
+	Long: `Show every status change of a project and how long each status lasted.
+Without a project name, the project containing the working directory is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := projectArg(cfg, args)
+		if err != nil {
+			return err
+		}
+
+		fmt.Printf("\n  📁 Project: %s\n", project.Name)
+		fmt.Printf("  📊 Status:  %s\n\n", formatStatus(cfg, config.StatusOf(project)))
+
+		if len(project.History) == 0 {
+			fmt.Printf("  %s\n\n", utils.MutedStyle.Render("No status changes recorded yet."))
+			return nil
+		}
+
+		spans := config.StatusSpans(project, time.Now())
+		for i, c := range project.History {
+			from := c.From
+			if from == "" {
+				from = config.NotSet
+			}
+			took := utils.FormatDuration(spans[i].Duration())
+			if i == len(spans)-1 {
+				took += " so far"
+			}
+
+			fmt.Printf("  %s  %s → %s  %s\n",
+				utils.MutedStyle.Render(c.At.Local().Format("2006-01-02 15:04")),
+				from, formatStatus(cfg, c.To),
+				utils.MutedStyle.Render("("+took+")"))
+			if c.Note != "" {
+				fmt.Printf("                    %s\n", c.Note)
+			}
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(historyCmd)
+}
@@ -1,34 +1,31 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 	"os/exec"
 	"path/filepath"
 
 	"github.com/henrynguci/orbit/internal/config"
-	"github.com/henrynguci/orbit/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var infoCmd = &cobra.Command{
 	Use:   "info [project]",
 	Short: "Show project README.md",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		projectName := args[0]
-
+	Long: `Show the README of a project in glow. Without a project name, the
+project containing the working directory is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
-			utils.PrintError("Failed to load config: " + err.Error())
-			return
+			return configError("load", err)
 		}
 
-		projectPath := config.FindProjectPath(cfg, projectName)
-		if projectPath == "" {
-			utils.PrintError(fmt.Sprintf("Project '%s' not found", projectName))
-			return
+		project, err := projectArg(cfg, args)
+		if err != nil {
+			return err
 		}
+		projectPath := project.Path
 
 		readmePath := filepath.Join(projectPath, "repo", "README.md")
 		if _, err := os.Stat(readmePath); os.IsNotExist(err) {
@@ -51,8 +48,7 @@ var infoCmd = &cobra.Command{
 					}
 				}
 				if !found {
-					utils.PrintError(fmt.Sprintf("README.md not found in %s", projectPath))
-					return
+					return notFoundError("README.md not found in %s", projectPath)
 				}
 			}
 		}
@@ -61,7 +57,10 @@ var infoCmd = &cobra.Command{
 		glowCmd.Stdin = os.Stdin
 		glowCmd.Stdout = os.Stdout
 		glowCmd.Stderr = os.Stderr
-		glowCmd.Run()
+		if err := glowCmd.Run(); err != nil {
+			return failedError("Failed to run glow: %w", err)
+		}
+		return nil
 	},
 }
 
@@ -6,6 +6,7 @@ import (
 	"path/filepath"
 
 	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/hooks"
 	"github.com/henrynguci/orbit/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -16,22 +17,20 @@ var initCmd = &cobra.Command{
 	Use:   "init [path]",
 	Short: "Initialize a new orbit workspace",
 	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
+	RunE: func(cmd *cobra.Command, args []string) error {
 		path := args[0]
 
 		if path[0] == '~' {
 			home, err := os.UserHomeDir()
 			if err != nil {
-				utils.PrintError("Failed to get home directory: " + err.Error())
-				return
+				return failedError("Failed to get home directory: %w", err)
 			}
 			path = filepath.Join(home, path[1:])
 		}
 
 		absPath, err := filepath.Abs(path)
 		if err != nil {
-			utils.PrintError("Failed to get absolute path: " + err.Error())
-			return
+			return failedError("Failed to get absolute path: %w", err)
 		}
 
 		cfg, err := config.Load()
@@ -42,8 +41,14 @@ var initCmd = &cobra.Command{
 			}
 		}
 
+		var createEvent *hooks.Event
 		if projectName != "" {
 			projectPath := filepath.Join(absPath, "project", projectName)
+			createEvent = &hooks.Event{Name: hooks.EventCreate, Project: projectName, Path: projectPath, Workspace: absPath, Status: config.InitialStatus(cfg)}
+			if err := hooks.Pre(cfg, *createEvent); err != nil {
+				return vetoedError(err)
+			}
+
 			dirs := []string{
 				filepath.Join(projectPath, "repo"),
 				filepath.Join(projectPath, "docs"),
@@ -52,22 +57,18 @@ var initCmd = &cobra.Command{
 
 			for _, dir := range dirs {
 				if err := os.MkdirAll(dir, 0755); err != nil {
-					utils.PrintError(fmt.Sprintf("Failed to create directory %s: %s", dir, err.Error()))
-					return
+					return failedError("Failed to create directory %s: %w", dir, err)
 				}
 			}
 
-			cfg.Projects[projectName] = config.Project{
-				Name:   projectName,
-				Path:   projectPath,
-				Status: "active",
-			}
+			project := config.Project{Name: projectName, Path: projectPath}
+			config.ChangeStatus(&project, config.InitialStatus(cfg), "")
+			cfg.Projects[projectName] = project
 
 			utils.PrintSuccess(fmt.Sprintf("Project '%s' created at %s", projectName, projectPath))
 		} else {
 			if err := os.MkdirAll(absPath, 0755); err != nil {
-				utils.PrintError(fmt.Sprintf("Failed to create workspace: %s", err.Error()))
-				return
+				return failedError("Failed to create workspace: %w", err)
 			}
 
 			utils.PrintSuccess(fmt.Sprintf("Workspace initialized at %s", absPath))
@@ -85,9 +86,15 @@ var initCmd = &cobra.Command{
 		}
 
 		if err := config.Save(cfg); err != nil {
-			utils.PrintError("Failed to save config: " + err.Error())
-			return
+			return configError("save", err)
+		}
+
+		if createEvent != nil {
+			if err := hooks.Post(cfg, *createEvent); err != nil {
+				utils.PrintWarning(err.Error())
+			}
 		}
+		return nil
 	},
 }
 
@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var linkRemove bool
+
+var linkCmd = &cobra.Command{
+	Use:   "link [project] [name] [url]",
+	Short: "List, set or remove the named links of a project",
+	Long: `Projects can keep named links such as their repository, deployed site or
+issue tracker. With only a project the links are listed; with a name and a
+URL the link is set.`,
+	Example: `  orbit link myproject
+  orbit link myproject site https://myproject.dev
+  orbit link myproject tracker --remove
+  orbit link open myproject site`,
+	Args: cobra.RangeArgs(1, 3),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := lookupProject(cfg, args[0])
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case len(args) == 1:
+			if linkRemove {
+				return invalidError("Name the link to remove")
+			}
+			printLinks(project)
+			return nil
+		case len(args) == 2 && !linkRemove:
+			url, ok := project.Links[args[1]]
+			if !ok {
+				return notFoundError("Project '%s' has no link '%s'", project.Name, args[1])
+			}
+			fmt.Println(url)
+			return nil
+		case len(args) == 3 && linkRemove:
+			return invalidError("Either give a URL or --remove, not both")
+		}
+
+		name := args[1]
+		if linkRemove {
+			if _, ok := project.Links[name]; !ok {
+				return notFoundError("Project '%s' has no link '%s'", project.Name, name)
+			}
+			delete(project.Links, name)
+		} else {
+			if project.Links == nil {
+				project.Links = make(map[string]string)
+			}
+			project.Links[name] = args[2]
+		}
+		config.UpdateProject(cfg, project)
+
+		if err := config.Save(cfg); err != nil {
+			return configError("save", err)
+		}
+
+		if linkRemove {
+			utils.PrintSuccess(fmt.Sprintf("Link '%s' removed from '%s'", name, project.Name))
+			return nil
+		}
+		utils.PrintSuccess(fmt.Sprintf("Link '%s' set for '%s'", name, project.Name))
+		return nil
+	},
+}
+
+var linkOpenCmd = &cobra.Command{
+	Use:   "open [project] [name]",
+	Short: "Open a project link in the browser",
+	Long: `Open a project link in the browser. The name can be left out when the
+project has a single link.
+
+Inside a project directory the project can be left out as well: a single
+argument naming one of its links opens that link, anything else is taken
+as a project name.`,
+	Example: `  orbit link open myproject site
+  orbit link open site      # inside myproject
+  orbit link open           # inside a project with a single link`,
+	Args: cobra.MaximumNArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, name, err := linkArgs(cfg, args)
+		if err != nil {
+			return err
+		}
+
+		names := config.LinkNames(project)
+		switch {
+		case name != "":
+		case len(names) == 1:
+			name = names[0]
+		case len(names) == 0:
+			return notFoundError("Project '%s' has no links", project.Name)
+		default:
+			return invalidError("Project '%s' has several links, name one: %s", project.Name, strings.Join(names, ", "))
+		}
+
+		url, ok := project.Links[name]
+		if !ok {
+			return notFoundError("Project '%s' has no link '%s'", project.Name, name)
+		}
+		if err := utils.OpenURL(url); err != nil {
+			return failedError("Failed to open %s: %w", url, err)
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Opened %s", url))
+		return nil
+	},
+}
+
+// linkArgs resolves the project and link name given to link open. A single
+// argument is a link name when the working directory is inside a project
+// that has such a link.
+func linkArgs(cfg *config.Config, args []string) (config.Project, string, error) {
+	if len(args) == 2 {
+		project, err := lookupProject(cfg, args[0])
+		return project, args[1], err
+	}
+	if len(args) == 1 {
+		if current, ok := config.CurrentProject(cfg); ok {
+			if _, ok := current.Links[args[0]]; ok {
+				return current, args[0], nil
+			}
+		}
+	}
+	project, err := projectArg(cfg, args)
+	return project, "", err
+}
+
+func printLinks(project config.Project) {
+	names := config.LinkNames(project)
+	if len(names) == 0 {
+		utils.PrintInfo(fmt.Sprintf("Project '%s' has no links", project.Name))
+		return
+	}
+
+	width := 0
+	for _, name := range names {
+		width = max(width, len(name))
+	}
+	for _, name := range names {
+		fmt.Printf("  %-*s  %s\n", width, name, project.Links[name])
+	}
+}
+
+func init() {
+	linkCmd.Flags().BoolVarP(&linkRemove, "remove", "r", false, "Remove the named link")
+	linkCmd.AddCommand(linkOpenCmd)
+	rootCmd.AddCommand(linkCmd)
+}
@@ -0,0 +1,190 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/journal"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	logAll   bool
+	logSince string
+)
+
+// feedEntry is a journal entry in the feed of every project.
+type feedEntry struct {
+	project string
+	journal.Entry
+}
+
+var logCmd = &cobra.Command{
+	Use:   "log [project] <message>",
+	Short: "Add a note to a project's journal",
+	Long: `Add a timestamped note to the journal of a project, kept in
+docs/journal.md. With only a message, the project containing the working
+directory is used.
+
+"orbit log show" prints the journal of a project, and "orbit log --all"
+the notes of every project together.`,
+	Example: `  orbit log api "Switched auth to sessions; next: migrate old tokens"
+  orbit log "Left off halfway through the importer"
+  orbit log show api
+  orbit log --all --since 7d`,
+	Args: func(cmd *cobra.Command, args []string) error {
+		if logAll {
+			return cobra.NoArgs(cmd, args)
+		}
+		return cobra.RangeArgs(1, 2)(cmd, args)
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseSince(logSince)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		if logAll {
+			return printFeed(cfg, since)
+		}
+		if logSince != "" {
+			return invalidError("--since needs --all, or use it with 'orbit log show'")
+		}
+
+		message := strings.TrimSpace(args[len(args)-1])
+		if message == "" {
+			return invalidError("The message is empty")
+		}
+		project, err := projectArg(cfg, args[:len(args)-1])
+		if err != nil {
+			return err
+		}
+		if project.Archive != "" {
+			return invalidError("Project '%s' is compacted; run 'orbit restore %s' first", project.Name, project.Name)
+		}
+		if _, err := os.Stat(project.Path); err != nil {
+			return notFoundError("Directory '%s' of project '%s' does not exist", project.Path, project.Name)
+		}
+
+		if err := journal.Append(project.Path, message, time.Now()); err != nil {
+			return failedError("Failed to write the journal: %w", err)
+		}
+		utils.PrintSuccess(fmt.Sprintf("Added a note to the journal of '%s'", project.Name))
+		return nil
+	},
+}
+
+var logShowCmd = &cobra.Command{
+	Use:   "show [project]",
+	Short: "Show a project's journal",
+	Long: `Show the notes in the journal of a project, oldest first. Without a
+project name, the project containing the working directory is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		since, err := parseSince(logSince)
+		if err != nil {
+			return err
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := projectArg(cfg, args)
+		if err != nil {
+			return err
+		}
+
+		entries, err := journal.Read(project.Path)
+		if err != nil {
+			return failedError("Failed to read the journal: %w", err)
+		}
+		entries = journal.Since(entries, since)
+
+		fmt.Printf("\n  📓 Journal: %s\n\n", project.Name)
+		if len(entries) == 0 {
+			fmt.Printf("  %s\n\n", utils.MutedStyle.Render("No notes yet."))
+			return nil
+		}
+		for _, e := range entries {
+			printNote(e, "")
+		}
+		return nil
+	},
+}
+
+// printFeed prints the notes of every project together, oldest first.
+func printFeed(cfg *config.Config, since time.Time) error {
+	projects, err := config.SelectProjects(cfg, config.Selector{})
+	if err != nil {
+		return invalidError("%w", err)
+	}
+
+	var feed []feedEntry
+	for _, p := range projects {
+		entries, err := journal.Read(p.Path)
+		if err != nil {
+			utils.PrintWarning(fmt.Sprintf("Failed to read the journal of '%s': %v", p.Name, err))
+			continue
+		}
+		for _, e := range journal.Since(entries, since) {
+			feed = append(feed, feedEntry{project: p.Name, Entry: e})
+		}
+	}
+	sort.SliceStable(feed, func(i, j int) bool { return feed[i].Time.Before(feed[j].Time) })
+
+	fmt.Printf("\n  📓 Journal\n\n")
+	if len(feed) == 0 {
+		fmt.Printf("  %s\n\n", utils.MutedStyle.Render("No notes yet."))
+		return nil
+	}
+	for _, e := range feed {
+		printNote(e.Entry, e.project)
+	}
+	return nil
+}
+
+func printNote(e journal.Entry, project string) {
+	heading := utils.MutedStyle.Render(e.Time.Format("2006-01-02 15:04"))
+	if project != "" {
+		heading += "  " + utils.InfoStyle.Render(project)
+	}
+	heading += "  " + utils.MutedStyle.Render(utils.FormatDuration(time.Since(e.Time))+" ago")
+
+	fmt.Printf("  %s\n", heading)
+	for _, line := range strings.Split(e.Text, "\n") {
+		fmt.Printf("    %s\n", line)
+	}
+	fmt.Println()
+}
+
+// parseSince turns a --since duration into the moment it reaches back to.
+// An empty duration reaches back to the beginning.
+func parseSince(s string) (time.Time, error) {
+	d, err := utils.ParseDuration(s)
+	if err != nil {
+		return time.Time{}, invalidError("%w", err)
+	}
+	if d == 0 {
+		return time.Time{}, nil
+	}
+	return time.Now().Add(-d), nil
+}
+
+func init() {
+	logCmd.Flags().BoolVarP(&logAll, "all", "a", false, "Show the notes of every project")
+	logCmd.PersistentFlags().StringVar(&logSince, "since", "", "Only show notes from this long ago, e.g. 7d, 2w or 12h")
+	logCmd.AddCommand(logShowCmd)
+	rootCmd.AddCommand(logCmd)
+}
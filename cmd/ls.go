@@ -1,18 +1,50 @@
 package cmd
 
 import (
+	"github.com/henrynguci/orbit/internal/config"
 	"github.com/henrynguci/orbit/internal/tui"
 	"github.com/spf13/cobra"
 )
 
+var (
+	lsWorkspace string
+	lsStatus    string
+	lsTag       string
+	lsSearch    string
+)
+
 var lsCmd = &cobra.Command{
 	Use:   "ls",
 	Short: "List all projects in TUI",
-	Run: func(cmd *cobra.Command, args []string) {
-		tui.RunMainTUI()
+	Long: `List all projects in the TUI. With any of the selector flags it opens on
+the dashboard, showing only the matching projects.`,
+	Example: `  orbit ls
+  orbit ls --tag go
+  orbit ls --search api --status active`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sel := config.Selector{
+			Workspace: lsWorkspace,
+			Status:    lsStatus,
+			Tag:       lsTag,
+			Search:    lsSearch,
+		}
+		var err error
+		if sel.IsEmpty() {
+			err = tui.RunMainTUI()
+		} else {
+			err = tui.RunDashboardTUI(sel)
+		}
+		if err != nil {
+			return configError("load", err)
+		}
+		return nil
 	},
 }
 
 func init() {
+	lsCmd.Flags().StringVarP(&lsWorkspace, "workspace", "w", "", "Only projects in this workspace (name or path)")
+	lsCmd.Flags().StringVarP(&lsStatus, "status", "s", "", "Only projects with this status")
+	lsCmd.Flags().StringVarP(&lsTag, "tag", "t", "", "Only projects with this tag")
+	lsCmd.Flags().StringVar(&lsSearch, "search", "", "Only projects with this text in their name, alias, description or tags")
 	rootCmd.AddCommand(lsCmd)
 }
@@ -0,0 +1,131 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/plugins"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// leadingFlags are the root flags that may come before a plugin name, as in
+// "orbit -q myplugin".
+var leadingFlags = map[string]*bool{
+	"-q":      &quietOutput,
+	"--quiet": &quietOutput,
+	"--json":  &jsonErrors,
+}
+
+// findPlugin finds the plugin named by the first argument after the leading
+// root flags, and returns the arguments that follow its name. The flags take
+// effect only when a plugin is found; otherwise cobra parses them as usual.
+func findPlugin(args []string) (*plugins.Plugin, []string, bool) {
+	var flags []*bool
+	for len(args) > 0 {
+		flag, ok := leadingFlags[args[0]]
+		if !ok {
+			break
+		}
+		flags = append(flags, flag)
+		args = args[1:]
+	}
+
+	if len(args) == 0 || strings.HasPrefix(args[0], "-") {
+		return nil, nil, false
+	}
+
+	if _, _, err := rootCmd.Find(args); err == nil {
+		return nil, nil, false
+	}
+
+	plugin, ok := plugins.Find(args[0])
+	if !ok {
+		return nil, nil, false
+	}
+	for _, flag := range flags {
+		*flag = true
+	}
+	utils.SetQuiet(quietOutput)
+	return plugin, args[1:], true
+}
+
+func runPlugin(plugin *plugins.Plugin, args []string) int {
+	cfg, _ := config.Load()
+
+	var project *config.Project
+	if cfg != nil {
+		if len(args) > 0 {
+			if p, exists := cfg.Projects[args[0]]; exists {
+				project = &p
+			}
+		}
+		if project == nil {
+			if cwd, err := os.Getwd(); err == nil {
+				if p, ok := config.ProjectForDir(cfg, cwd); ok {
+					project = &p
+				}
+			}
+		}
+	}
+
+	c := plugin.Command(args, project, cfg)
+	if quietOutput {
+		c.Env = append(c.Env, "ORBIT_QUIET=1")
+	}
+	if jsonErrors {
+		c.Env = append(c.Env, "ORBIT_JSON=1")
+	}
+	c.Stdin = os.Stdin
+	c.Stdout = os.Stdout
+	c.Stderr = os.Stderr
+
+	if err := c.Run(); err != nil {
+		var exitErr *exec.ExitError
+		if errors.As(err, &exitErr) {
+			return childExitCode(exitErr)
+		}
+		return reportError(failedError("Failed to run plugin '%s': %w", plugin.Name, err))
+	}
+	return 0
+}
+
+func addPluginCommands() {
+	for _, plugin := range plugins.List() {
+		if _, _, err := rootCmd.Find([]string{plugin.Name}); err == nil {
+			continue
+		}
+
+		short := plugin.Manifest.Description
+		if short == "" {
+			short = plugin.Path
+		}
+
+		p := plugin
+		rootCmd.AddCommand(&cobra.Command{
+			Use:                p.Name,
+			Short:              fmt.Sprintf("[plugin] %s", short),
+			DisableFlagParsing: true,
+			Run: func(cmd *cobra.Command, args []string) {
+				os.Exit(runPlugin(&p, args))
+			},
+		})
+	}
+}
+
+func init() {
+	defaultHelp := rootCmd.HelpFunc()
+	rootCmd.SetHelpFunc(func(cmd *cobra.Command, args []string) {
+		if cmd == rootCmd {
+			addPluginCommands()
+			if dir, err := plugins.Dir(); err == nil {
+				defer fmt.Printf("\n%s\n", utils.MutedStyle.Render("Plugins are orbit-<name> executables on PATH or in "+dir+"."))
+			}
+		}
+		defaultHelp(cmd, args)
+	})
+}
@@ -0,0 +1,29 @@
+package cmd
+
+import "github.com/henrynguci/orbit/internal/config"
+
+// lookupProject finds a project by name or alias. Projects that exist on
+// disk but are not in the config yet come back with just a name and path.
+func lookupProject(cfg *config.Config, name string) (config.Project, error) {
+	if project, exists := cfg.Projects[name]; exists {
+		return project, nil
+	}
+
+	projectPath := config.FindProjectPath(cfg, name)
+	if projectPath == "" {
+		return config.Project{}, notFoundError("Project '%s' not found", name)
+	}
+	return config.Project{Name: name, Path: projectPath}, nil
+}
+
+// projectArg returns the project named by the first argument, or the project
+// containing the working directory when no argument is given.
+func projectArg(cfg *config.Config, args []string) (config.Project, error) {
+	if len(args) > 0 {
+		return lookupProject(cfg, args[0])
+	}
+	if project, ok := config.CurrentProject(cfg); ok {
+		return project, nil
+	}
+	return config.Project{}, invalidError("Give a project name, or run this inside a project directory")
+}
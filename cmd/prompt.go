@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"os"
+	"strings"
+	"text/template"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/theme"
+	"github.com/muesli/termenv"
+	"github.com/spf13/cobra"
+)
+
+const defaultPromptFormat = `{{with .Icon}}{{.}} {{end}}{{.Name}}{{with .Alias}} ({{.}}){{end}} {{color .Color .Status}}`
+
+var (
+	promptFormat   string
+	promptShell    string
+	promptStarship bool
+)
+
+var promptCmd = &cobra.Command{
+	Use:   "prompt",
+	Short: "Print the current project for a shell prompt",
+	Long: `Print the project containing the working directory, for use in a shell
+prompt. Outside a project nothing is printed and the exit code is 3.
+
+The format is a Go template with .Name, .Alias, .Status, .Icon, .Workspace
+and .Path, and a "color" function that paints text in a colour such as
+.Color, the colour of the status. The default is:
+
+  ` + defaultPromptFormat + `
+
+Colours are wrapped in the markers bash or zsh need to measure the prompt
+when --shell is given. With --starship no colours are printed, since
+starship styles the module itself.
+
+The project is read from a small index written with the config, so the
+command takes a few milliseconds.`,
+	Example: `  PS1='$(orbit prompt --shell bash) \w \$ '
+  orbit prompt --format '{{.Workspace}}/{{.Name}}'
+  orbit prompt --starship`,
+	Args: cobra.NoArgs,
+	// Skip loading the config and theme for every prompt.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if promptShell != "" && promptShell != "bash" && promptShell != "zsh" {
+			return invalidError("Unknown shell '%s'. Valid: bash, zsh", promptShell)
+		}
+
+		tmpl, err := template.New("prompt").Funcs(template.FuncMap{
+			"color": promptColor,
+		}).Parse(promptFormat)
+		if err != nil {
+			return invalidError("Invalid format: %w", err)
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return silentError(exitGeneral)
+		}
+		index, err := config.ReadIndex()
+		if err != nil {
+			return configError("load", err)
+		}
+		entry, ok := index.Find(cwd)
+		if !ok {
+			return silentError(exitNotFound)
+		}
+		if entry.Color != "" {
+			entry.Color = indexPalette(index).Lookup(entry.Color)
+		}
+
+		var out strings.Builder
+		if err := tmpl.Execute(&out, entry); err != nil {
+			return invalidError("Invalid format: %w", err)
+		}
+		os.Stdout.WriteString(out.String())
+		return nil
+	},
+}
+
+// indexPalette resolves the theme stored in the index, falling back to the
+// default theme when it is broken.
+func indexPalette(index config.Index) theme.Palette {
+	palette, err := theme.Resolve(index.Theme, index.Themes)
+	if err != nil {
+		palette, _ = theme.Resolve("", nil)
+	}
+	return palette
+}
+
+// promptColor paints text in c, a hex colour or an ANSI colour number.
+func promptColor(c, text string) string {
+	if promptStarship || c == "" || os.Getenv("NO_COLOR") != "" {
+		return text
+	}
+
+	profile := termenv.ANSI256
+	if ct := os.Getenv("COLORTERM"); ct == "truecolor" || ct == "24bit" {
+		profile = termenv.TrueColor
+	}
+	color := profile.Color(c)
+	if color == nil {
+		return text
+	}
+
+	start, reset := "\x1b["+color.Sequence(false)+"m", "\x1b[0m"
+	switch promptShell {
+	case "bash":
+		start, reset = `\[`+start+`\]`, `\[`+reset+`\]`
+	case "zsh":
+		start, reset = "%{"+start+"%}", "%{"+reset+"%}"
+	}
+	return start + text + reset
+}
+
+func init() {
+	promptCmd.Flags().StringVarP(&promptFormat, "format", "f", defaultPromptFormat, "Go template for the output")
+	promptCmd.Flags().StringVar(&promptShell, "shell", "", "Wrap colours for the prompt of this shell (bash, zsh)")
+	promptCmd.Flags().BoolVar(&promptStarship, "starship", false, "Print plain text for a starship custom module")
+	rootCmd.AddCommand(promptCmd)
+}
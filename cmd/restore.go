@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/henrynguci/orbit/internal/archive"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/hooks"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var restoreCmd = &cobra.Command{
+	Use:   "restore [project]",
+	Short: "Unpack a compacted project back into its workspace",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, exists := cfg.Projects[projectName]
+		if !exists {
+			return notFoundError("Project '%s' not found", projectName)
+		}
+
+		if project.Archive == "" {
+			return failedError("Project '%s' is not compacted", projectName)
+		}
+
+		if _, err := os.Stat(project.Path); err == nil {
+			return failedError("Directory '%s' already exists", project.Path)
+		}
+
+		ev := hooks.Event{
+			Name:      hooks.EventRestore,
+			Project:   project.Name,
+			Path:      project.Path,
+			Workspace: config.WorkspaceOf(cfg, project),
+			Status:    project.Status,
+		}
+		if err := hooks.Pre(cfg, ev); err != nil {
+			return vetoedError(err)
+		}
+
+		if err := archive.Extract(project.Archive, filepath.Dir(project.Path)); err != nil {
+			return failedError("Failed to restore project: %w", err)
+		}
+
+		archivePath := project.Archive
+		project.Archive = ""
+		config.UpdateProject(cfg, project)
+
+		if err := config.Save(cfg); err != nil {
+			return configError("save", err)
+		}
+
+		if err := os.Remove(archivePath); err != nil {
+			utils.PrintWarning("Failed to remove archive: " + err.Error())
+		}
+
+		if err := hooks.Post(cfg, ev); err != nil {
+			utils.PrintWarning(err.Error())
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Project '%s' restored to %s", projectName, project.Path))
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(restoreCmd)
+}
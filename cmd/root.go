@@ -1,31 +1,54 @@
 package cmd
 
 import (
-	"fmt"
 	"os"
 
+	"github.com/henrynguci/orbit/internal/config"
 	"github.com/henrynguci/orbit/internal/tui"
+	"github.com/henrynguci/orbit/internal/utils"
 	"github.com/spf13/cobra"
 )
 
 var version = "1.0.0"
 
+var (
+	quietOutput bool
+	jsonErrors  bool
+)
+
 var rootCmd = &cobra.Command{
-	Use:     "orbit",
-	Short:   "Keep your side projects in orbit 🚀",
-	Version: version,
-	Run: func(cmd *cobra.Command, args []string) {
-		tui.RunMainTUI()
+	Use:           "orbit",
+	Short:         "Keep your side projects in orbit 🚀",
+	Version:       version,
+	SilenceErrors: true,
+	SilenceUsage:  true,
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {
+		utils.SetQuiet(quietOutput)
+		if cfg, err := config.Load(); err == nil {
+			applyConfiguredTheme(cfg)
+			warnStatusConfig(cfg)
+		}
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := tui.RunMainTUI(); err != nil {
+			return configError("load", err)
+		}
+		return nil
 	},
 }
 
 func Execute() {
+	if plugin, args, ok := findPlugin(os.Args[1:]); ok {
+		os.Exit(runPlugin(plugin, args))
+	}
+
 	if err := rootCmd.Execute(); err != nil {
-		fmt.Fprintln(os.Stderr, err)
-		os.Exit(1)
+		os.Exit(reportError(err))
 	}
 }
 
 func init() {
 	rootCmd.CompletionOptions.DisableDefaultCmd = true
+	rootCmd.PersistentFlags().BoolVarP(&quietOutput, "quiet", "q", false, "Suppress output; rely on the exit code")
+	rootCmd.PersistentFlags().BoolVar(&jsonErrors, "json", false, "Report errors as JSON on stderr")
 }
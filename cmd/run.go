@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/frecency"
+	"github.com/henrynguci/orbit/internal/projectfile"
+	"github.com/henrynguci/orbit/internal/tasks"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [project] [task]",
+	Short: "Run a project task, or list tasks when none is given",
+	Long: `Run a named task defined in the project's orbit.json, or one detected
+from the repo (go.mod, package.json, pyproject.toml, Makefile).
+
+Tasks run in repo/ by default, with secret/.env loaded into the environment.`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		projectName := args[0]
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		projectPath := config.FindProjectPath(cfg, projectName)
+		if projectPath == "" {
+			return notFoundError("Project '%s' not found", projectName)
+		}
+
+		projectTasks, err := tasks.Resolve(projectPath)
+		if err != nil {
+			return failedError("Failed to read %s: %w", projectfile.FileName, err)
+		}
+
+		if len(args) == 1 {
+			if len(projectTasks) == 0 {
+				utils.PrintInfo(fmt.Sprintf("No tasks found. Define them in %s", projectfile.Path(projectPath)))
+				return nil
+			}
+
+			fmt.Printf("\n")
+			for _, t := range projectTasks {
+				source := ""
+				if t.Detected {
+					source = utils.MutedStyle.Render(" (detected)")
+				}
+				fmt.Printf("  %s%s\n", utils.InfoStyle.Render(t.Name), source)
+				fmt.Printf("      %s\n", t.Cmd)
+			}
+			fmt.Printf("\n")
+			return nil
+		}
+
+		task, ok := tasks.Lookup(projectTasks, args[1])
+		if !ok {
+			return notFoundError("Task '%s' not found for project '%s'", args[1], projectName)
+		}
+
+		frecency.Visit(projectPath)
+
+		taskCmd := tasks.Command(projectPath, task)
+		taskCmd.Stdin = os.Stdin
+		taskCmd.Stdout = os.Stdout
+		taskCmd.Stderr = os.Stderr
+
+		utils.PrintInfo(fmt.Sprintf("%s › %s", task.Name, task.Cmd))
+		if err := taskCmd.Run(); err != nil {
+			var exitErr *exec.ExitError
+			if errors.As(err, &exitErr) {
+				return &cmdError{kind: "task", code: childExitCode(exitErr), err: fmt.Errorf("Task '%s' failed: %w", task.Name, err)}
+			}
+			return failedError("Failed to run task '%s': %w", task.Name, err)
+		}
+		return nil
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(runCmd)
+}
@@ -5,63 +5,191 @@ import (
 	"strings"
 
 	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/hooks"
 	"github.com/henrynguci/orbit/internal/utils"
 	"github.com/spf13/cobra"
 )
 
-var validStatuses = []string{"active", "archived", "done", "not set"}
+var (
+	setWorkspace string
+	setTag       string
+	setSearch    string
+	setInactive  string
+	setDryRun    bool
+	setNote      string
+)
 
 var setCmd = &cobra.Command{
-	Use:   "set [project] [status]",
+	Use:   "set [project|pattern] [status]",
 	Short: "Set project status",
-	Args:  cobra.ExactArgs(2),
-	Run: func(cmd *cobra.Command, args []string) {
-		projectName := args[0]
-		status := strings.ToLower(args[1])
-
-		valid := false
-		for _, s := range validStatuses {
-			if status == s {
-				valid = true
-				break
-			}
+	Long: `Set the status of one project, or of every project matching a selector.
+
+Projects can be selected by a glob on the name or alias, by workspace, by
+tag, by text in the name, description or tags, or by inactivity. All
+selected projects are updated in a single config write.
+
+The statuses and the moves allowed between them come from "statuses" in the
+config; projects that may not move to the new status are skipped.
+
+With only a status and no selector flags, the project containing the working
+directory is set.`,
+	Example: `  orbit set myproject done
+  orbit set done
+  orbit set 'hack-*' archived
+  orbit set myproject paused -m "waiting on the API"
+  orbit set --workspace side --inactive 90d archived --dry-run`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		status := args[len(args)-1]
+
+		inactive, err := utils.ParseDuration(setInactive)
+		if err != nil {
+			return invalidError("%w", err)
+		}
+
+		sel := config.Selector{
+			Workspace:   setWorkspace,
+			Tag:         setTag,
+			Search:      setSearch,
+			InactiveFor: inactive,
 		}
-		if !valid {
-			utils.PrintError(fmt.Sprintf("Invalid status '%s'. Valid: %s", status, strings.Join(validStatuses, ", ")))
-			return
+		projectName := ""
+		if len(args) == 2 {
+			projectName = args[0]
+			if config.IsPattern(projectName) {
+				sel.Pattern = projectName
+			}
 		}
 
 		cfg, err := config.Load()
 		if err != nil {
-			utils.PrintError("Failed to load config: " + err.Error())
-			return
+			return configError("load", err)
 		}
 
-		project, exists := cfg.Projects[projectName]
-		if !exists {
-			projectPath := config.FindProjectPath(cfg, projectName)
-			if projectPath == "" {
-				utils.PrintError(fmt.Sprintf("Project '%s' not found", projectName))
-				return
+		if sel.IsEmpty() && projectName == "" {
+			project, ok := config.CurrentProject(cfg)
+			if !ok {
+				return invalidError("Specify a project, a pattern or at least one selector flag, or run this inside a project directory")
 			}
-			project = config.Project{
-				Name: projectName,
-				Path: projectPath,
+			projectName = project.Name
+		}
+
+		target, ok := config.LookupStatus(cfg, status)
+		if !ok {
+			return invalidError("Invalid status '%s'. Valid: %s", status, strings.Join(config.StatusNames(cfg), ", "))
+		}
+		status = target.Name
+
+		var projects []config.Project
+		if projectName != "" && sel.Pattern == "" {
+			project, exists := cfg.Projects[projectName]
+			if !exists {
+				projectPath := config.FindProjectPath(cfg, projectName)
+				if projectPath == "" {
+					return notFoundError("Project '%s' not found", projectName)
+				}
+				project = config.Project{
+					Name: projectName,
+					Path: projectPath,
+				}
+			}
+			if matched, _ := sel.Match(cfg, project); !matched {
+				return notFoundError("Project '%s' does not match the given selectors", projectName)
+			}
+			projects = append(projects, project)
+		} else {
+			projects, err = config.SelectProjects(cfg, sel)
+			if err != nil {
+				return invalidError("Invalid pattern '%s': %w", sel.Pattern, err)
+			}
+			if len(projects) == 0 {
+				return notFoundError("No projects match the given selectors")
 			}
 		}
 
-		project.Status = status
-		cfg.Projects[projectName] = project
+		if setDryRun {
+			utils.PrintInfo(fmt.Sprintf("Would set %d project(s) to %s:", len(projects), status))
+			for _, p := range projects {
+				line := fmt.Sprintf("  %s  %s → %s", p.Name, utils.MutedStyle.Render(config.StatusOf(p)), formatStatus(cfg, status))
+				if err := config.CheckTransition(cfg, p.Status, status); err != nil {
+					line += "  " + utils.WarningStyle.Render("("+err.Error()+")")
+				}
+				utils.PrintDetail(line)
+			}
+			return nil
+		}
+
+		var changed []config.Project
+		var events []hooks.Event
+		vetoed := 0
+		for _, p := range projects {
+			if err := config.CheckTransition(cfg, p.Status, status); err != nil {
+				if len(projects) == 1 {
+					return invalidError("Project '%s': %v", p.Name, err)
+				}
+				utils.PrintWarning(fmt.Sprintf("Skipping '%s': %v", p.Name, err))
+				continue
+			}
+
+			ev := hooks.Event{
+				Name:           hooks.EventStatus,
+				Project:        p.Name,
+				Path:           p.Path,
+				Workspace:      config.WorkspaceOf(cfg, p),
+				Status:         status,
+				PreviousStatus: p.Status,
+			}
+			if err := hooks.Pre(cfg, ev); err != nil {
+				if len(projects) == 1 {
+					return vetoedError(err)
+				}
+				utils.PrintWarning(err.Error())
+				vetoed++
+				continue
+			}
+
+			config.ChangeStatus(&p, status, setNote)
+			config.UpdateProject(cfg, p)
+			changed = append(changed, p)
+			events = append(events, ev)
+		}
+
+		if len(changed) == 0 {
+			if vetoed == 0 {
+				return invalidError("None of the projects may move to %s", status)
+			}
+			return vetoedError(fmt.Errorf("All status changes were vetoed by hooks"))
+		}
 
 		if err := config.Save(cfg); err != nil {
-			utils.PrintError("Failed to save config: " + err.Error())
-			return
+			return configError("save", err)
+		}
+
+		for _, ev := range events {
+			if err := hooks.Post(cfg, ev); err != nil {
+				utils.PrintWarning(err.Error())
+			}
+		}
+
+		if len(changed) == 1 {
+			utils.PrintSuccess(fmt.Sprintf("Project '%s' status set to %s", changed[0].Name, formatStatus(cfg, status)))
+			return nil
 		}
 
-		utils.PrintSuccess(fmt.Sprintf("Project '%s' status set to %s", projectName, status))
+		utils.PrintSuccess(fmt.Sprintf("%d projects set to %s", len(changed), formatStatus(cfg, status)))
+		for _, p := range changed {
+			utils.PrintDetail("  " + p.Name)
+		}
+		return nil
 	},
 }
 
 func init() {
+	setCmd.Flags().StringVarP(&setWorkspace, "workspace", "w", "", "Only projects in this workspace (name or path)")
+	setCmd.Flags().StringVarP(&setTag, "tag", "t", "", "Only projects with this tag")
+	setCmd.Flags().StringVar(&setSearch, "search", "", "Only projects with this text in their name, alias, description or tags")
+	setCmd.Flags().StringVar(&setInactive, "inactive", "", "Only projects with no status change, commit or file change for this long (e.g. 90d)")
+	setCmd.Flags().BoolVarP(&setDryRun, "dry-run", "n", false, "Preview the change without writing the config")
+	setCmd.Flags().StringVarP(&setNote, "note", "m", "", "Note to keep in the status history")
 	rootCmd.AddCommand(setCmd)
 }
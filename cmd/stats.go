@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// The statuses the finished and archived sections look for.
+const (
+	statsFinished = "done"
+	statsActive   = "active"
+	statsArchived = "archived"
+)
+
+// statsMonths is how many months of finished projects are shown.
+const statsMonths = 12
+
+var (
+	statsWorkspace string
+	statsTag       string
+)
+
+var statsCmd = &cobra.Command{
+	Use:   "stats",
+	Short: "Show how long projects spend in each status",
+	Long: `Show statistics built from the status history of your projects: the time
+spent in each status, the projects finished per month and how long projects
+stay active before they are archived.
+
+Only status changes made since orbit started recording history are counted.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		projects, err := config.SelectProjects(cfg, config.Selector{Workspace: statsWorkspace, Tag: statsTag})
+		if err != nil {
+			return invalidError("%v", err)
+		}
+
+		// Spans are kept by path, since projects in different workspaces
+		// may share a name.
+		now := time.Now()
+		spans := make(map[string][]config.StatusSpan)
+		for _, p := range projects {
+			spans[p.Path] = config.StatusSpans(p, now)
+		}
+
+		fmt.Println()
+		printTimeInStatus(cfg, projects, spans)
+		printFinishedPerMonth(projects, now)
+		printActiveBeforeArchived(projects, spans)
+		return nil
+	},
+}
+
+func printTimeInStatus(cfg *config.Config, projects []config.Project, spans map[string][]config.StatusSpan) {
+	fmt.Println(" " + utils.TitleStyle.Render("Time in status"))
+
+	total := make(map[string]time.Duration)
+	members := make(map[string]map[string]bool)
+	var statuses []string
+	for _, p := range projects {
+		for _, s := range spans[p.Path] {
+			if _, ok := members[s.Status]; !ok {
+				members[s.Status] = make(map[string]bool)
+				statuses = append(statuses, s.Status)
+			}
+			total[s.Status] += s.Duration()
+			members[s.Status][p.Path] = true
+		}
+	}
+	// Workflow order, with statuses the config no longer knows at the end.
+	sort.Slice(statuses, func(i, j int) bool {
+		ri, rj := config.StatusRank(cfg, statuses[i]), config.StatusRank(cfg, statuses[j])
+		if ri != rj {
+			return ri < rj
+		}
+		return statuses[i] < statuses[j]
+	})
+
+	if len(total) == 0 {
+		fmt.Printf("    %s\n\n", utils.MutedStyle.Render("No status changes recorded yet."))
+		return
+	}
+
+	for _, status := range statuses {
+		count := len(members[status])
+		fmt.Printf("    %s %3d projects  total %6s  average %6s\n",
+			lipgloss.NewStyle().Width(14).Render(formatStatus(cfg, status)),
+			count,
+			utils.FormatDuration(total[status]),
+			utils.FormatDuration(total[status]/time.Duration(count)))
+	}
+	fmt.Println()
+}
+
+func printFinishedPerMonth(projects []config.Project, now time.Time) {
+	fmt.Println(" " + utils.TitleStyle.Render(fmt.Sprintf("Finished per month (%s)", statsFinished)))
+
+	counts := make(map[string]int)
+	for _, p := range projects {
+		for _, c := range p.History {
+			if c.To == statsFinished {
+				counts[c.At.Local().Format("2006-01")]++
+			}
+		}
+	}
+
+	if len(counts) == 0 {
+		fmt.Printf("    %s\n\n", utils.MutedStyle.Render("No projects finished yet."))
+		return
+	}
+
+	first := time.Date(now.Year(), now.Month()-(statsMonths-1), 1, 0, 0, 0, 0, time.Local)
+	for i := 0; i < statsMonths; i++ {
+		month := first.AddDate(0, i, 0).Format("2006-01")
+		n := counts[month]
+		bar := utils.SuccessStyle.Render(strings.Repeat("█", n))
+		fmt.Printf("    %s  %2d %s\n", month, n, bar)
+	}
+	fmt.Println()
+}
+
+func printActiveBeforeArchived(projects []config.Project, spans map[string][]config.StatusSpan) {
+	fmt.Println(" " + utils.TitleStyle.Render("Active before archived"))
+
+	type stint struct {
+		project string
+		length  time.Duration
+	}
+	var stints []stint
+	for _, p := range projects {
+		for _, s := range spans[p.Path] {
+			if s.Status == statsActive && s.Next == statsArchived {
+				stints = append(stints, stint{project: p.Name, length: s.Duration()})
+			}
+		}
+	}
+
+	if len(stints) == 0 {
+		fmt.Printf("    %s\n\n", utils.MutedStyle.Render("No project has gone from "+statsActive+" to "+statsArchived+" yet."))
+		return
+	}
+
+	sort.Slice(stints, func(i, j int) bool { return stints[i].length < stints[j].length })
+	var sum time.Duration
+	for _, s := range stints {
+		sum += s.length
+	}
+	longest := stints[len(stints)-1]
+
+	fmt.Printf("    %d times  average %s  median %s  longest %s (%s)\n\n",
+		len(stints),
+		utils.FormatDuration(sum/time.Duration(len(stints))),
+		utils.FormatDuration(stints[len(stints)/2].length),
+		utils.FormatDuration(longest.length), longest.project)
+}
+
+func init() {
+	statsCmd.Flags().StringVarP(&statsWorkspace, "workspace", "w", "", "Only projects in this workspace (name or path)")
+	statsCmd.Flags().StringVarP(&statsTag, "tag", "t", "", "Only projects with this tag")
+	rootCmd.AddCommand(statsCmd)
+}
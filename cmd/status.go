@@ -2,8 +2,11 @@ package cmd
 
 import (
 	"fmt"
+	"strings"
 
+	"github.com/charmbracelet/lipgloss"
 	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/theme"
 	"github.com/henrynguci/orbit/internal/utils"
 	"github.com/spf13/cobra"
 )
@@ -11,47 +14,50 @@ import (
 var statusCmd = &cobra.Command{
 	Use:   "status [project]",
 	Short: "Get project status",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		projectName := args[0]
-
+	Long: `Get the status of a project. Without a project name, the project
+containing the working directory is used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
 		cfg, err := config.Load()
 		if err != nil {
-			utils.PrintError("Failed to load config: " + err.Error())
-			return
-		}
-
-		project, exists := cfg.Projects[projectName]
-		if !exists {
-			projectPath := config.FindProjectPath(cfg, projectName)
-			if projectPath == "" {
-				utils.PrintError(fmt.Sprintf("Project '%s' not found", projectName))
-				return
-			}
-
-			project = config.Project{
-				Name:   projectName,
-				Path:   projectPath,
-				Status: "active",
-			}
+			return configError("load", err)
 		}
 
-		status := project.Status
-		if status == "" {
-			status = "active"
+		project, err := projectArg(cfg, args)
+		if err != nil {
+			return err
 		}
 
 		fmt.Printf("\n")
-		fmt.Printf("  📁 Project: %s\n", projectName)
+		fmt.Printf("  📁 Project: %s\n", project.Name)
 		if project.Alias != "" {
 			fmt.Printf("  🏷️  Alias:   %s\n", project.Alias)
 		}
-		fmt.Printf("  📊 Status:  %s\n", status)
+		fmt.Printf("  📊 Status:  %s\n", formatStatus(cfg, config.StatusOf(project)))
 		fmt.Printf("  📍 Path:    %s\n", project.Path)
 		fmt.Printf("\n")
+		return nil
 	},
 }
 
+// formatStatus renders a status with the colour and icon from the config.
+func formatStatus(cfg *config.Config, name string) string {
+	s, ok := config.LookupStatus(cfg, name)
+	if !ok {
+		return name
+	}
+	return lipgloss.NewStyle().Foreground(theme.Named(s.Color)).Render(s.Label())
+}
+
+// warnStatusConfig points out mistakes in the configured statuses.
+func warnStatusConfig(cfg *config.Config) {
+	if err := config.ValidateStatuses(cfg); err != nil {
+		for _, line := range strings.Split(err.Error(), "\n") {
+			utils.PrintWarning(line)
+		}
+	}
+}
+
 func init() {
 	rootCmd.AddCommand(statusCmd)
 }
@@ -0,0 +1,72 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var tagRemove bool
+
+var tagCmd = &cobra.Command{
+	Use:   "tag [project] [tags...]",
+	Short: "List, add or remove project tags",
+	Long: `List the tags of a project, or add the given tags to it.
+
+Tags can be used to select projects in "exec", "set", "stats" and "ls" with
+--tag, and in the TUI filter.`,
+	Example: `  orbit tag myproject
+  orbit tag myproject go cli
+  orbit tag myproject --remove cli`,
+	Args: cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := lookupProject(cfg, args[0])
+		if err != nil {
+			return err
+		}
+
+		tags := args[1:]
+		if len(tags) == 0 {
+			if tagRemove {
+				return invalidError("Name the tags to remove")
+			}
+			if len(project.Tags) == 0 {
+				utils.PrintInfo(fmt.Sprintf("Project '%s' has no tags", project.Name))
+				return nil
+			}
+			fmt.Println(strings.Join(project.Tags, "\n"))
+			return nil
+		}
+
+		if tagRemove {
+			config.RemoveTags(&project, tags...)
+		} else {
+			config.AddTags(&project, tags...)
+		}
+		config.UpdateProject(cfg, project)
+
+		if err := config.Save(cfg); err != nil {
+			return configError("save", err)
+		}
+
+		if len(project.Tags) == 0 {
+			utils.PrintSuccess(fmt.Sprintf("Project '%s' has no tags left", project.Name))
+			return nil
+		}
+		utils.PrintSuccess(fmt.Sprintf("Project '%s' tags: %s", project.Name, strings.Join(project.Tags, ", ")))
+		return nil
+	},
+}
+
+func init() {
+	tagCmd.Flags().BoolVarP(&tagRemove, "remove", "r", false, "Remove the given tags instead of adding them")
+	rootCmd.AddCommand(tagCmd)
+}
@@ -0,0 +1,83 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/theme"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var themeCmd = &cobra.Command{
+	Use:   "theme [name]",
+	Short: "List colour themes or switch to one",
+	Long: `List colour themes or switch to one.
+
+The built-in themes are "dark" and "light". Custom themes go under "themes" in
+the config; each names a "base" theme and the colours it changes, e.g.
+{"themes": {"solar": {"base": "light", "primary": "#268BD2"}}}.
+Set "ascii_borders" to draw borders with plain ASCII. NO_COLOR is respected.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		if len(args) == 0 {
+			printThemes(cfg)
+			return nil
+		}
+
+		name := args[0]
+		if _, err := theme.Resolve(name, cfg.Themes); err != nil {
+			return notFoundError("%v", err)
+		}
+
+		cfg.Theme = name
+		if err := config.Save(cfg); err != nil {
+			return configError("save", err)
+		}
+		theme.Apply(name, cfg.Themes, cfg.ASCIIBorders)
+
+		utils.PrintSuccess(fmt.Sprintf("Theme set to '%s'", name))
+		return nil
+	},
+}
+
+func printThemes(cfg *config.Config) {
+	current := theme.Current().Name
+	for _, name := range theme.Names(cfg.Themes) {
+		palette, err := theme.Resolve(name, cfg.Themes)
+		if err != nil {
+			fmt.Printf("  %-12s %s\n", name, utils.ErrorStyle.Render(err.Error()))
+			continue
+		}
+
+		var swatch string
+		for _, c := range []string{palette.Primary, palette.Secondary, palette.Success, palette.Warning, palette.Error} {
+			swatch += lipgloss.NewStyle().Foreground(theme.Color(c)).Render("●")
+		}
+
+		marker := "  "
+		if name == current {
+			marker = utils.SuccessStyle.Render("✓ ")
+		}
+		fmt.Printf("%s%-12s %s\n", marker, name, swatch)
+	}
+}
+
+// applyConfiguredTheme switches to the theme named in the config. A broken
+// theme only warns, so it never stops a command from running.
+func applyConfiguredTheme(cfg *config.Config) {
+	if err := theme.Apply(cfg.Theme, cfg.Themes, cfg.ASCIIBorders); err != nil {
+		utils.PrintWarning(fmt.Sprintf("%v; using the default theme", err))
+		theme.Apply("", nil, cfg.ASCIIBorders)
+	}
+}
+
+func init() {
+	rootCmd.AddCommand(themeCmd)
+}
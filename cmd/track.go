@@ -0,0 +1,196 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/timelog"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+// timeBarWidth is the length of the bar of the project with the most time.
+const timeBarWidth = 30
+
+var timeWeek bool
+
+var startCmd = &cobra.Command{
+	Use:   "start [project]",
+	Short: "Start tracking time on a project",
+	Long: `Start a time tracking session on a project, stopping the running one.
+Without a project name, the project containing the working directory is
+used.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		project, err := projectArg(cfg, args)
+		if err != nil {
+			return err
+		}
+
+		running, err := timelog.Current()
+		if err != nil {
+			return failedError("Failed to read the running session: %w", err)
+		}
+		if running != nil && running.Path == project.Path {
+			utils.PrintInfo(fmt.Sprintf("Already tracking '%s' for %s", project.Name, utils.FormatHours(running.Duration(time.Now()))))
+			return nil
+		}
+
+		stopped, err := timelog.Start(project.Name, project.Path, false)
+		if err != nil {
+			return failedError("Failed to start the session: %w", err)
+		}
+		if stopped != nil {
+			utils.PrintInfo(fmt.Sprintf("Stopped '%s' after %s", stopped.Project, utils.FormatHours(stopped.Duration(stopped.End))))
+		}
+		utils.PrintSuccess(fmt.Sprintf("Tracking time on '%s'", project.Name))
+		return nil
+	},
+}
+
+var stopCmd = &cobra.Command{
+	Use:   "stop",
+	Short: "Stop tracking time",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		stopped, err := timelog.Stop()
+		if err != nil {
+			return failedError("Failed to stop the session: %w", err)
+		}
+		if stopped == nil {
+			return notFoundError("No session is running")
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Stopped '%s' after %s", stopped.Project, utils.FormatHours(stopped.Duration(stopped.End))))
+		return nil
+	},
+}
+
+var timeCmd = &cobra.Command{
+	Use:   "time",
+	Short: "Show the time tracked per project",
+	Long: `Show the time tracked on each project, in total or this week.
+
+Sessions are started with "orbit start", by goto and open when "auto_track"
+is set in the config, and by the shell hook. To let the hook follow you
+around, run "orbit time hook" from your prompt:
+
+  bash:  PROMPT_COMMAND="orbit time hook; $PROMPT_COMMAND"
+  zsh:   autoload -Uz add-zsh-hook && add-zsh-hook precmd orbit_time_hook
+         orbit_time_hook() { orbit time hook }
+
+The hook starts a session when you enter a project and stops it when you
+leave, or after 15 minutes without a prompt. Sessions started with
+"orbit start" are left alone.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		sessions, err := timelog.Sessions()
+		if err != nil {
+			return failedError("Failed to read the sessions: %w", err)
+		}
+		running, err := timelog.Current()
+		if err != nil {
+			return failedError("Failed to read the running session: %w", err)
+		}
+		if running != nil {
+			sessions = append(sessions, *running)
+		}
+
+		now := time.Now()
+		title := "Time tracked"
+		var since time.Time
+		if timeWeek {
+			title = "Time this week"
+			since = timelog.WeekStart(now)
+		}
+
+		fmt.Println()
+		fmt.Println(" " + utils.TitleStyle.Render(title))
+
+		totals := timelog.Totals(sessions, since, now)
+		if len(totals) == 0 {
+			fmt.Printf("    %s\n\n", utils.MutedStyle.Render("No time tracked yet."))
+			return nil
+		}
+
+		width := 0
+		var sum time.Duration
+		for _, t := range totals {
+			width = max(width, len(t.Project))
+			sum += t.Duration
+		}
+		for _, t := range totals {
+			bar := int(float64(timeBarWidth) * float64(t.Duration) / float64(totals[0].Duration))
+			fmt.Printf("    %-*s  %7s  %s\n", width, t.Project, utils.FormatHours(t.Duration),
+				utils.InfoStyle.Render(strings.Repeat("█", max(bar, 1))))
+		}
+		fmt.Printf("    %-*s  %7s\n", width, "total", utils.FormatHours(sum))
+
+		if running != nil {
+			fmt.Printf("\n    %s\n", utils.MutedStyle.Render(fmt.Sprintf("Tracking '%s' for %s", running.Project, utils.FormatHours(running.Duration(now)))))
+		}
+		fmt.Println()
+		return nil
+	},
+}
+
+var timeHookCmd = &cobra.Command{
+	Use:    "hook",
+	Short:  "Start or stop automatic sessions from the shell prompt",
+	Hidden: true,
+	Args:   cobra.NoArgs,
+	// The hook runs before every prompt, so it skips loading the config
+	// and never reports errors.
+	PersistentPreRun: func(cmd *cobra.Command, args []string) {},
+	Run: func(cmd *cobra.Command, args []string) {
+		now := time.Now()
+		if _, err := timelog.StopIdle(now); err != nil {
+			return
+		}
+		running, err := timelog.Current()
+		if err != nil {
+			return
+		}
+		if running != nil && !running.Auto {
+			return
+		}
+
+		cwd, err := os.Getwd()
+		if err != nil {
+			return
+		}
+		entry, ok, err := config.LookupIndex(cwd)
+		if err != nil {
+			return
+		}
+
+		switch {
+		case !ok:
+			if running != nil {
+				timelog.StopAt(now)
+			}
+		case running != nil && running.Path == entry.Path:
+			if now.Sub(running.Last) > time.Minute {
+				timelog.Touch(*running)
+			}
+		default:
+			timelog.Start(entry.Name, entry.Path, true)
+		}
+	},
+}
+
+func init() {
+	timeCmd.Flags().BoolVarP(&timeWeek, "week", "w", false, "Only count this week, from Monday")
+	timeCmd.AddCommand(timeHookCmd)
+	rootCmd.AddCommand(startCmd)
+	rootCmd.AddCommand(stopCmd)
+	rootCmd.AddCommand(timeCmd)
+}
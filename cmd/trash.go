@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/trash"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/mattn/go-isatty"
+	"github.com/spf13/cobra"
+)
+
+var (
+	trashOlderThan string
+	trashYes       bool
+)
+
+var trashCmd = &cobra.Command{
+	Use:   "trash",
+	Short: "Manage deleted projects and workspaces",
+}
+
+var trashLsCmd = &cobra.Command{
+	Use:   "ls",
+	Short: "List items in the trash",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entries, err := trash.List()
+		if err != nil {
+			return failedError("Failed to read trash: %w", err)
+		}
+
+		if len(entries) == 0 {
+			utils.PrintInfo("Trash is empty")
+			return nil
+		}
+
+		fmt.Printf("\n")
+		for _, e := range entries {
+			files := "config only"
+			if e.HasFiles {
+				files = "with files"
+			}
+			fmt.Printf("  🗑️  %s\n", utils.InfoStyle.Render(e.ID))
+			fmt.Printf("      %s '%s' (%s), deleted %s\n", e.Kind, e.Name, files, e.DeletedAt.Format("02/01/2006 15:04"))
+			fmt.Printf("      %s\n", utils.MutedStyle.Render(e.OriginalPath))
+		}
+		fmt.Printf("\n")
+		return nil
+	},
+}
+
+var trashRestoreCmd = &cobra.Command{
+	Use:   "restore [id]",
+	Short: "Restore an item from the trash",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		entry, err := trash.Restore(args[0])
+		if err != nil {
+			return failedError("Failed to restore: %w", err)
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Restored %s '%s' to %s", entry.Kind, entry.Name, entry.OriginalPath))
+		return nil
+	},
+}
+
+var trashEmptyCmd = &cobra.Command{
+	Use:   "empty",
+	Short: "Permanently delete items from the trash",
+	Long: `Permanently delete items from the trash. This cannot be undone, so it
+asks first; pass --yes to skip the question, which is required when the
+input is not a terminal.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		olderThan, err := utils.ParseDuration(trashOlderThan)
+		if err != nil {
+			return invalidError("%w", err)
+		}
+
+		expired, err := trash.Expired(olderThan)
+		if err != nil {
+			return failedError("Failed to read trash: %w", err)
+		}
+		if len(expired) == 0 {
+			utils.PrintInfo("Nothing to remove from the trash")
+			return nil
+		}
+
+		if !trashYes {
+			if !isatty.IsTerminal(os.Stdin.Fd()) {
+				return invalidError("Refusing to empty the trash without --yes")
+			}
+			if !confirm(fmt.Sprintf("Permanently delete %d item(s) from the trash?", len(expired))) {
+				utils.PrintInfo("Nothing removed")
+				return nil
+			}
+		}
+
+		removed, err := trash.Empty(olderThan)
+		if err != nil {
+			return failedError("Failed to empty trash: %w", err)
+		}
+
+		utils.PrintSuccess(fmt.Sprintf("Removed %d item(s) from the trash", removed))
+		return nil
+	},
+}
+
+// confirm asks a yes/no question on the terminal, defaulting to no.
+func confirm(question string) bool {
+	fmt.Printf("%s [y/N] ", question)
+	answer, _ := bufio.NewReader(os.Stdin).ReadString('\n')
+	answer = strings.ToLower(strings.TrimSpace(answer))
+	return answer == "y" || answer == "yes"
+}
+
+func init() {
+	trashEmptyCmd.Flags().StringVar(&trashOlderThan, "older-than", "", "Only remove items deleted before this age (e.g. 30d, 12h)")
+	trashEmptyCmd.Flags().BoolVarP(&trashYes, "yes", "y", false, "Do not ask for confirmation")
+	trashCmd.AddCommand(trashLsCmd, trashRestoreCmd, trashEmptyCmd)
+	rootCmd.AddCommand(trashCmd)
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/frecency"
+	"github.com/henrynguci/orbit/internal/hooks"
+	"github.com/henrynguci/orbit/internal/timelog"
+	"github.com/henrynguci/orbit/internal/utils"
+	"github.com/spf13/cobra"
+)
+
+var (
+	zPrint bool
+	zList  bool
+)
+
+var zCmd = &cobra.Command{
+	Use:   "z [fragments...]",
+	Short: "Jump to the best matching project",
+	Long: `Jump to the project whose name, alias or path contains every fragment,
+preferring an exact name or alias, then the project visited most often and
+most recently.
+
+Projects are visited by opening them from the TUI, going to them, running
+their tasks, and by "orbit z" itself. Without --print a shell is started in
+the project directory; with --print the path is printed so a shell function
+can cd there:
+
+  oz() { cd "$(orbit z --print "$@")"; }
+
+The open hooks run either way. With --print their output goes to stderr, and
+a pre-open hook that fails prints no path.`,
+	Example: `  orbit z api
+  orbit z side web
+  orbit z --list`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if len(args) == 0 && !zList {
+			return invalidError("Give a fragment of the project name or path")
+		}
+
+		cfg, err := config.Load()
+		if err != nil {
+			return configError("load", err)
+		}
+
+		visits, err := frecency.Load()
+		if err != nil {
+			return failedError("Failed to read the visits: %w", err)
+		}
+
+		matches := rankProjects(cfg, visits, args)
+		if zList {
+			printRanking(matches, visits)
+			return nil
+		}
+		if len(matches) == 0 {
+			return notFoundError("No project matches '%s'", strings.Join(args, " "))
+		}
+
+		project := matches[0]
+		ev := hooks.Event{
+			Name:      hooks.EventOpen,
+			Project:   project.Name,
+			Path:      project.Path,
+			Workspace: config.WorkspaceOf(cfg, project),
+			Status:    project.Status,
+			Tool:      "shell",
+		}
+		if zPrint {
+			// Only the path may reach stdout, where the shell function reads it.
+			hooks.SetOutput(os.Stderr)
+			defer hooks.SetOutput(nil)
+		}
+		if err := hooks.Pre(cfg, ev); err != nil {
+			return vetoedError(err)
+		}
+
+		frecency.Visit(project.Path)
+		if cfg.AutoTrack {
+			timelog.Start(project.Name, project.Path, true)
+		}
+
+		if zPrint {
+			fmt.Println(project.Path)
+			if err := hooks.Post(cfg, ev); err != nil {
+				utils.PrintWarning(err.Error())
+			}
+			return nil
+		}
+
+		if err := os.Chdir(project.Path); err != nil {
+			return failedError("Failed to access directory: %w", err)
+		}
+		if err := hooks.Post(cfg, ev); err != nil {
+			utils.PrintWarning(err.Error())
+		}
+		if err := utils.ExecShell(); err != nil {
+			return failedError("Failed to start a shell: %w", err)
+		}
+		return nil
+	},
+}
+
+// rankProjects returns the projects matching every fragment, best first.
+func rankProjects(cfg *config.Config, visits map[string]frecency.Entry, fragments []string) []config.Project {
+	now := time.Now()
+	exact := func(p config.Project) bool {
+		return len(fragments) == 1 && (strings.EqualFold(p.Name, fragments[0]) || strings.EqualFold(p.Alias, fragments[0]))
+	}
+
+	var matches []config.Project
+	for _, p := range config.GetAllProjects(cfg) {
+		if p.Path == "" {
+			continue
+		}
+		if len(fragments) == 0 && visits[p.Path].Rank == 0 {
+			continue
+		}
+
+		text := strings.ToLower(p.Name + " " + p.Alias + " " + p.Path)
+		matched := true
+		for _, f := range fragments {
+			if !strings.Contains(text, strings.ToLower(f)) {
+				matched = false
+				break
+			}
+		}
+		if matched {
+			matches = append(matches, p)
+		}
+	}
+
+	sort.SliceStable(matches, func(i, j int) bool {
+		a, b := matches[i], matches[j]
+		if exact(a) != exact(b) {
+			return exact(a)
+		}
+		if sa, sb := visits[a.Path].Score(now), visits[b.Path].Score(now); sa != sb {
+			return sa > sb
+		}
+		return a.Name < b.Name
+	})
+	return matches
+}
+
+func printRanking(projects []config.Project, visits map[string]frecency.Entry) {
+	if len(projects) == 0 {
+		utils.PrintInfo("No visited projects match")
+		return
+	}
+
+	now := time.Now()
+	width := 0
+	for _, p := range projects {
+		width = max(width, len(p.Name))
+	}
+	for _, p := range projects {
+		score := fmt.Sprintf("%6.1f", visits[p.Path].Score(now))
+		fmt.Printf("  %s  %-*s  %s\n", utils.InfoStyle.Render(score), width, p.Name, utils.MutedStyle.Render(p.Path))
+	}
+}
+
+func init() {
+	zCmd.Flags().BoolVarP(&zPrint, "print", "p", false, "Print the project path instead of starting a shell there")
+	zCmd.Flags().BoolVarP(&zList, "list", "l", false, "List the matching projects with their scores")
+	rootCmd.AddCommand(zCmd)
+}
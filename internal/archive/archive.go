@@ -0,0 +1,222 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+func ArchiveName(projectName string) string {
+	return fmt.Sprintf("%s-%s.tar.gz", projectName, time.Now().Format("20060102-150405"))
+}
+
+func Compact(srcDir, archivePath string) (int64, error) {
+	if err := os.MkdirAll(filepath.Dir(archivePath), 0755); err != nil {
+		return 0, err
+	}
+
+	f, err := os.OpenFile(archivePath, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0644)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := writeTarball(f, srcDir); err != nil {
+		f.Close()
+		os.Remove(archivePath)
+		return 0, err
+	}
+
+	if err := f.Close(); err != nil {
+		os.Remove(archivePath)
+		return 0, err
+	}
+
+	return Size(archivePath), nil
+}
+
+func writeTarball(w io.Writer, srcDir string) error {
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	base := filepath.Base(srcDir)
+	err := filepath.Walk(srcDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(srcDir, path)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(filepath.Join(base, rel))
+
+		link := ""
+		if info.Mode()&os.ModeSymlink != 0 {
+			link, err = os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			// Extract refuses such links, so fail now rather than pack a
+			// project that cannot be restored.
+			if !linkInside(srcDir, path, link) {
+				return fmt.Errorf("symlink %s points outside the project: %s", rel, link)
+			}
+		}
+
+		header, err := tar.FileInfoHeader(info, link)
+		if err != nil {
+			return err
+		}
+		header.Name = name
+		if info.IsDir() {
+			header.Name += "/"
+		}
+
+		if err := tw.WriteHeader(header); err != nil {
+			return err
+		}
+
+		if !info.Mode().IsRegular() {
+			return nil
+		}
+
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+
+		_, err = io.Copy(tw, file)
+		return err
+	})
+	if err != nil {
+		return err
+	}
+
+	if err := tw.Close(); err != nil {
+		return err
+	}
+	return gz.Close()
+}
+
+// Extract unpacks an archive into destDir. The entries are unpacked into a
+// temporary directory first and moved into place only when all of them were
+// written, so a failed extract leaves nothing behind.
+func Extract(archivePath, destDir string) error {
+	tmpDir, err := os.MkdirTemp(destDir, ".orbit-restore-*")
+	if err != nil {
+		return err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	if err := extractTo(archivePath, tmpDir); err != nil {
+		return err
+	}
+
+	entries, err := os.ReadDir(tmpDir)
+	if err != nil {
+		return err
+	}
+	for _, e := range entries {
+		if _, err := os.Lstat(filepath.Join(destDir, e.Name())); err == nil {
+			return fmt.Errorf("%s already exists", filepath.Join(destDir, e.Name()))
+		}
+	}
+	for _, e := range entries {
+		if err := os.Rename(filepath.Join(tmpDir, e.Name()), filepath.Join(destDir, e.Name())); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func extractTo(archivePath, destDir string) error {
+	f, err := os.Open(archivePath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	gz, err := gzip.NewReader(f)
+	if err != nil {
+		return err
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		target := filepath.Join(destDir, filepath.FromSlash(header.Name))
+		if !isInside(destDir, target) {
+			return fmt.Errorf("illegal path in archive: %s", header.Name)
+		}
+
+		mode := os.FileMode(header.Mode).Perm()
+		switch header.Typeflag {
+		case tar.TypeDir:
+			if err := os.MkdirAll(target, mode|0700); err != nil {
+				return err
+			}
+		case tar.TypeSymlink:
+			if !linkInside(destDir, target, header.Linkname) {
+				return fmt.Errorf("illegal symlink in archive: %s -> %s", header.Name, header.Linkname)
+			}
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			if err := os.Symlink(header.Linkname, target); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			if err := os.MkdirAll(filepath.Dir(target), 0755); err != nil {
+				return err
+			}
+			out, err := os.OpenFile(target, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(out, tr); err != nil {
+				out.Close()
+				return err
+			}
+			if err := out.Close(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// isInside reports whether path is root or lies below it.
+func isInside(root, path string) bool {
+	return path == root || strings.HasPrefix(path, root+string(os.PathSeparator))
+}
+
+// linkInside reports whether a symlink at path pointing to linkname stays
+// within root. Absolute links are never allowed, since they would point
+// elsewhere once the project is restored on another machine or path.
+func linkInside(root, path, linkname string) bool {
+	if filepath.IsAbs(linkname) {
+		return false
+	}
+	return isInside(filepath.Clean(root), filepath.Join(filepath.Dir(path), linkname))
+}
+
+func Size(archivePath string) int64 {
+	info, err := os.Stat(archivePath)
+	if err != nil {
+		return 0
+	}
+	return info.Size()
+}
@@ -0,0 +1,170 @@
+package archive
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// writeArchive builds a tarball from headers, giving regular files a short
+// body.
+func writeArchive(t *testing.T, headers ...*tar.Header) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "crafted.tar.gz")
+	f, err := os.Create(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	gz := gzip.NewWriter(f)
+	tw := tar.NewWriter(gz)
+	for _, h := range headers {
+		body := ""
+		if h.Typeflag == tar.TypeReg {
+			body = "pwned"
+			h.Size = int64(len(body))
+		}
+		if err := tw.WriteHeader(h); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	for _, c := range []interface{ Close() error }{tw, gz, f} {
+		if err := c.Close(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return path
+}
+
+func assertEmpty(t *testing.T, dir string) {
+	t.Helper()
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("%s is not empty after a failed extract: %v", dir, entries)
+	}
+}
+
+func TestCompactAndExtract(t *testing.T) {
+	src := filepath.Join(t.TempDir(), "demo")
+	if err := os.MkdirAll(filepath.Join(src, "repo", "cmd"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(src, "repo", "cmd", "main.go"), []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink("repo/cmd/main.go", filepath.Join(src, "main.go")); err != nil {
+		t.Fatal(err)
+	}
+
+	archivePath := filepath.Join(t.TempDir(), "demo.tar.gz")
+	size, err := Compact(src, archivePath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if size == 0 {
+		t.Error("Compact reported an empty archive")
+	}
+
+	dest := t.TempDir()
+	if err := Extract(archivePath, dest); err != nil {
+		t.Fatal(err)
+	}
+	data, err := os.ReadFile(filepath.Join(dest, "demo", "main.go"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "package main\n" {
+		t.Errorf("restored file through the symlink = %q", data)
+	}
+
+	entries, _ := os.ReadDir(dest)
+	if len(entries) != 1 {
+		t.Errorf("Extract left %d entries in the destination, want only the project", len(entries))
+	}
+}
+
+func TestCompactRejectsLinksOutsideProject(t *testing.T) {
+	for _, link := range []string{"/etc/passwd", "../outside"} {
+		src := filepath.Join(t.TempDir(), "demo")
+		if err := os.MkdirAll(src, 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.Symlink(link, filepath.Join(src, "link")); err != nil {
+			t.Fatal(err)
+		}
+
+		archivePath := filepath.Join(t.TempDir(), "demo.tar.gz")
+		if _, err := Compact(src, archivePath); err == nil {
+			t.Errorf("Compact accepted a symlink to %s", link)
+		}
+		if _, err := os.Stat(archivePath); !os.IsNotExist(err) {
+			t.Errorf("Compact left an archive behind for a symlink to %s", link)
+		}
+	}
+}
+
+func TestExtractRejectsCraftedEntries(t *testing.T) {
+	tests := []struct {
+		name    string
+		headers []*tar.Header
+	}{
+		{"path traversal", []*tar.Header{
+			{Name: "demo/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "../escaped", Typeflag: tar.TypeReg, Mode: 0644},
+		}},
+		{"absolute symlink", []*tar.Header{
+			{Name: "demo/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "demo/etc", Typeflag: tar.TypeSymlink, Linkname: "/etc"},
+		}},
+		{"symlink out of the destination", []*tar.Header{
+			{Name: "demo/", Typeflag: tar.TypeDir, Mode: 0755},
+			{Name: "demo/up", Typeflag: tar.TypeSymlink, Linkname: "../.."},
+			{Name: "demo/up/escaped", Typeflag: tar.TypeReg, Mode: 0644},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			root := t.TempDir()
+			dest := filepath.Join(root, "workspace")
+			if err := os.Mkdir(dest, 0755); err != nil {
+				t.Fatal(err)
+			}
+
+			err := Extract(writeArchive(t, tt.headers...), dest)
+			if err == nil || !strings.Contains(err.Error(), "illegal") {
+				t.Fatalf("Extract error = %v, want an illegal entry", err)
+			}
+			assertEmpty(t, dest)
+			if _, err := os.Stat(filepath.Join(root, "escaped")); !os.IsNotExist(err) {
+				t.Error("a file was written outside the destination")
+			}
+		})
+	}
+}
+
+func TestExtractRefusesExistingProject(t *testing.T) {
+	archivePath := writeArchive(t,
+		&tar.Header{Name: "demo/", Typeflag: tar.TypeDir, Mode: 0755},
+		&tar.Header{Name: "demo/file", Typeflag: tar.TypeReg, Mode: 0644},
+	)
+	dest := t.TempDir()
+	if err := os.Mkdir(filepath.Join(dest, "demo"), 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := Extract(archivePath, dest); err == nil {
+		t.Fatal("Extract overwrote an existing project")
+	}
+	if _, err := os.Stat(filepath.Join(dest, "demo", "file")); !os.IsNotExist(err) {
+		t.Error("Extract wrote into the existing project")
+	}
+}
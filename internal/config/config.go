@@ -4,21 +4,59 @@ import (
 	"encoding/json"
 	"os"
 	"path/filepath"
+	"sort"
+	"strings"
 )
 
 type Project struct {
-	Name   string `json:"name"`
-	Alias  string `json:"alias,omitempty"`
-	Path   string `json:"path"`
-	Status string `json:"status"`
+	Name    string   `json:"name"`
+	Alias   string   `json:"alias,omitempty"`
+	Path    string   `json:"path"`
+	Status  string   `json:"status"`
+	Archive string   `json:"archive,omitempty"`
+	Tags    []string `json:"tags,omitempty"`
+	// Description is a one-line summary of the project.
+	Description string `json:"description,omitempty"`
+	// Links are named URLs such as "repo", "site" or "tracker".
+	Links map[string]string `json:"links,omitempty"`
+	// History records every status change, oldest first.
+	History []StatusChange `json:"history,omitempty"`
+}
+
+// SortOrder is the column a TUI table is sorted by.
+type SortOrder struct {
+	Column string `json:"column"`
+	Desc   bool   `json:"desc,omitempty"`
+}
+
+// KeyConfig picks a TUI keybinding preset and rebinds single actions.
+type KeyConfig struct {
+	Preset   string              `json:"preset,omitempty"`
+	Bindings map[string][]string `json:"bindings,omitempty"`
 }
 
 type Config struct {
-	Workspaces []string           `json:"workspaces"`
-	Projects   map[string]Project `json:"projects"`
+	Workspaces      []string             `json:"workspaces"`
+	Projects        map[string]Project   `json:"projects"`
+	ArchiveRoot     string               `json:"archive_root,omitempty"`
+	ExecConcurrency int                  `json:"exec_concurrency,omitempty"`
+	Hooks           map[string][]string  `json:"hooks,omitempty"`
+	Sort            map[string]SortOrder `json:"sort,omitempty"`
+	Theme           string               `json:"theme,omitempty"`
+	// Themes are custom themes by name. Each maps "base" and palette
+	// entries such as "primary" to plain strings; the theme package
+	// resolves them.
+	Themes        map[string]map[string]string `json:"themes,omitempty"`
+	ASCIIBorders  bool                         `json:"ascii_borders,omitempty"`
+	Keys          KeyConfig                    `json:"keys,omitzero"`
+	Statuses      []Status                     `json:"statuses,omitempty"`
+	InitialStatus string                       `json:"initial_status,omitempty"`
+	// AutoTrack starts a time tracking session on a project when it is
+	// opened or visited with goto.
+	AutoTrack bool `json:"auto_track,omitempty"`
 }
 
-func getConfigPath() (string, error) {
+func GetConfigDir() (string, error) {
 	home, err := os.UserHomeDir()
 	if err != nil {
 		return "", err
@@ -27,9 +65,43 @@ func getConfigPath() (string, error) {
 	if err := os.MkdirAll(configDir, 0755); err != nil {
 		return "", err
 	}
+	return configDir, nil
+}
+
+func Path() (string, error) {
+	return getConfigPath()
+}
+
+func getConfigPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
 	return filepath.Join(configDir, "orbit.json"), nil
 }
 
+func GetArchiveRoot(cfg *Config) (string, error) {
+	if cfg.ArchiveRoot != "" {
+		return ExpandPath(cfg.ArchiveRoot)
+	}
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "archive"), nil
+}
+
+func ExpandPath(path string) (string, error) {
+	if strings.HasPrefix(path, "~") {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", err
+		}
+		path = filepath.Join(home, path[1:])
+	}
+	return filepath.Abs(path)
+}
+
 func Load() (*Config, error) {
 	configPath, err := getConfigPath()
 	if err != nil {
@@ -71,7 +143,49 @@ func Save(cfg *Config) error {
 		return err
 	}
 
-	return os.WriteFile(configPath, data, 0644)
+	tmp, err := os.CreateTemp(filepath.Dir(configPath), "orbit-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Chmod(0644); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+
+	if err := os.Rename(tmp.Name(), configPath); err != nil {
+		return err
+	}
+
+	// The index is only a cache for the prompt, so failing to write it is
+	// not worth failing the save for.
+	writeIndex(cfg)
+	return nil
+}
+
+func UpdateProject(cfg *Config, project Project) {
+	cfg.Projects[project.Name] = project
+	if project.Alias != "" && project.Alias != project.Name {
+		cfg.Projects[project.Alias] = project
+	}
+}
+
+// LinkNames returns the names of a project's links, sorted.
+func LinkNames(project Project) []string {
+	names := make([]string, 0, len(project.Links))
+	for name := range project.Links {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
 }
 
 func FindProjectPath(cfg *Config, projectName string) string {
@@ -90,12 +204,60 @@ func FindProjectPath(cfg *Config, projectName string) string {
 	return ""
 }
 
+// ProjectForDir finds the registered project containing dir by walking up
+// from dir to the first directory that is a project path. Symlinks in dir
+// are resolved if the path as given is not inside a project.
+func ProjectForDir(cfg *Config, dir string) (Project, bool) {
+	byPath := make(map[string]Project)
+	for name, project := range cfg.Projects {
+		if project.Path == "" || name == project.Alias {
+			continue
+		}
+		if project.Name == "" {
+			project.Name = name
+		}
+		byPath[filepath.Clean(project.Path)] = project
+	}
+
+	return findDir(byPath, dir)
+}
+
+// findDir walks up from dir to the first directory that is a key of byPath,
+// trying the path with symlinks resolved if the path as given finds nothing.
+func findDir[T any](byPath map[string]T, dir string) (T, bool) {
+	dirs := []string{dir}
+	if resolved, err := filepath.EvalSymlinks(dir); err == nil && resolved != dir {
+		dirs = append(dirs, resolved)
+	}
+	for _, d := range dirs {
+		for d = filepath.Clean(d); ; d = filepath.Dir(d) {
+			if v, ok := byPath[d]; ok {
+				return v, true
+			}
+			if d == filepath.Dir(d) {
+				break
+			}
+		}
+	}
+	var zero T
+	return zero, false
+}
+
+// CurrentProject is the registered project containing the working directory.
+func CurrentProject(cfg *Config) (Project, bool) {
+	cwd, err := os.Getwd()
+	if err != nil {
+		return Project{}, false
+	}
+	return ProjectForDir(cfg, cwd)
+}
+
 func GetAllProjects(cfg *Config) []Project {
 	projects := []Project{}
 	seenPaths := make(map[string]bool)
 
-	for _, project := range cfg.Projects {
-		if project.Alias == "" && !seenPaths[project.Path] {
+	for name, project := range cfg.Projects {
+		if name != project.Alias && !seenPaths[project.Path] {
 			projects = append(projects, project)
 			seenPaths[project.Path] = true
 		}
@@ -117,7 +279,7 @@ func GetAllProjects(cfg *Config) []Project {
 				continue
 			}
 
-			status := "not set"
+			status := NotSet
 			if existing, exists := cfg.Projects[entry.Name()]; exists {
 				status = existing.Status
 			}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+// The index is a small copy of the registered projects, written next to the
+// config on every save. Shell prompts read it instead of the whole config,
+// so they answer in a few milliseconds.
+
+// Index is the content of the index file. The theme is stored as it is in
+// the config, so that readers can resolve the status colours themselves.
+type Index struct {
+	Theme    string                       `json:"theme,omitempty"`
+	Themes   map[string]map[string]string `json:"themes,omitempty"`
+	Projects []IndexEntry                 `json:"projects"`
+}
+
+// IndexEntry is what a prompt needs to know about a project.
+type IndexEntry struct {
+	Name   string `json:"name"`
+	Alias  string `json:"alias,omitempty"`
+	Path   string `json:"path"`
+	Status string `json:"status"`
+	Icon   string `json:"icon,omitempty"`
+	// Color is the status colour as configured: a palette entry such as
+	// "success", or a colour. It is empty for unknown statuses.
+	Color     string `json:"color,omitempty"`
+	Workspace string `json:"workspace,omitempty"`
+}
+
+func getIndexPath() (string, error) {
+	configDir, err := GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "index.json"), nil
+}
+
+// buildIndex returns the index of the registered projects.
+func buildIndex(cfg *Config) Index {
+	index := Index{Theme: cfg.Theme, Themes: cfg.Themes, Projects: []IndexEntry{}}
+	for name, p := range cfg.Projects {
+		if p.Path == "" || name == p.Alias {
+			continue
+		}
+
+		e := IndexEntry{
+			Name:   name,
+			Alias:  p.Alias,
+			Path:   filepath.Clean(p.Path),
+			Status: StatusOf(p),
+		}
+		if s, ok := LookupStatus(cfg, e.Status); ok {
+			e.Icon = s.Icon
+			e.Color = s.Color
+			if e.Color == "" {
+				e.Color = "text"
+			}
+		}
+		if w := WorkspaceOf(cfg, p); w != "" {
+			e.Workspace = filepath.Base(w)
+		}
+		index.Projects = append(index.Projects, e)
+	}
+	return index
+}
+
+func writeIndex(cfg *Config) error {
+	indexPath, err := getIndexPath()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(buildIndex(cfg))
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(indexPath), "index-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), indexPath)
+}
+
+// ReadIndex returns the index. The index file is used when it is at least as
+// new as the config and can be read; otherwise, such as after the config was
+// edited by hand, the config itself is read. Nothing is written either way.
+// Without a config the index is empty.
+func ReadIndex() (Index, error) {
+	configPath, err := getConfigPath()
+	if err != nil {
+		return Index{}, err
+	}
+	indexPath, err := getIndexPath()
+	if err != nil {
+		return Index{}, err
+	}
+
+	configInfo, err := os.Stat(configPath)
+	if os.IsNotExist(err) {
+		return Index{}, nil
+	}
+	if err != nil {
+		return Index{}, err
+	}
+
+	if indexInfo, err := os.Stat(indexPath); err == nil && !indexInfo.ModTime().Before(configInfo.ModTime()) {
+		if index, err := readIndex(indexPath); err == nil {
+			return index, nil
+		}
+	}
+
+	cfg, err := Load()
+	if err != nil {
+		return Index{}, err
+	}
+	return buildIndex(cfg), nil
+}
+
+// Find returns the project containing dir.
+func (index Index) Find(dir string) (IndexEntry, bool) {
+	byPath := make(map[string]IndexEntry, len(index.Projects))
+	for _, e := range index.Projects {
+		byPath[e.Path] = e
+	}
+	return findDir(byPath, dir)
+}
+
+// LookupIndex finds the registered project containing dir in the index.
+func LookupIndex(dir string) (IndexEntry, bool, error) {
+	index, err := ReadIndex()
+	if err != nil {
+		return IndexEntry{}, false, err
+	}
+	e, ok := index.Find(dir)
+	return e, ok, nil
+}
+
+func readIndex(indexPath string) (Index, error) {
+	data, err := os.ReadFile(indexPath)
+	if err != nil {
+		return Index{}, err
+	}
+
+	var index Index
+	if err := json.Unmarshal(data, &index); err != nil {
+		return Index{}, err
+	}
+	return index, nil
+}
@@ -0,0 +1,197 @@
+package config
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+)
+
+type Selector struct {
+	Pattern     string
+	Workspace   string
+	Tag         string
+	Status      string
+	InactiveFor time.Duration
+	// Search matches text in the name, alias, description or tags.
+	Search string
+}
+
+func IsPattern(name string) bool {
+	return strings.ContainsAny(name, "*?[")
+}
+
+func (s Selector) IsEmpty() bool {
+	return s.Pattern == "" && s.Workspace == "" && s.Tag == "" && s.Status == "" && s.InactiveFor == 0 && s.Search == ""
+}
+
+func (s Selector) Match(cfg *Config, project Project) (bool, error) {
+	if s.Pattern != "" {
+		matched, err := filepath.Match(s.Pattern, project.Name)
+		if err != nil {
+			return false, err
+		}
+		if !matched && project.Alias != "" {
+			matched, _ = filepath.Match(s.Pattern, project.Alias)
+		}
+		if !matched {
+			return false, nil
+		}
+	}
+
+	if s.Workspace != "" {
+		workspace := WorkspaceOf(cfg, project)
+		if workspace == "" || (workspace != s.Workspace && filepath.Base(workspace) != s.Workspace) {
+			return false, nil
+		}
+	}
+
+	if s.Tag != "" && !HasTag(project, s.Tag) {
+		return false, nil
+	}
+
+	if s.Status != "" {
+		if !strings.EqualFold(StatusOf(project), s.Status) {
+			return false, nil
+		}
+	}
+
+	if s.Search != "" && !MatchesText(project, s.Search) {
+		return false, nil
+	}
+
+	if s.InactiveFor > 0 && ActiveSince(project, time.Now().Add(-s.InactiveFor)) {
+		return false, nil
+	}
+
+	return true, nil
+}
+
+// maxActivityFiles bounds the walk ActiveSince makes through a project.
+const maxActivityFiles = 10000
+
+// activitySkipDirs are not walked by ActiveSince: dependencies change when
+// they are installed rather than when the project is worked on, and the git
+// directory is checked through its HEAD log instead.
+var activitySkipDirs = map[string]bool{
+	".git":         true,
+	"node_modules": true,
+	".venv":        true,
+	"venv":         true,
+	"vendor":       true,
+}
+
+// ActiveSince reports whether a project shows any activity after t: a
+// status change in its history, a commit or checkout recorded in
+// .git/logs/HEAD of the project or its repo/, or a file or folder modified
+// anywhere in its tree. The walk stops at the first sign of activity. A
+// project too large to walk within maxActivityFiles entries counts as
+// active, so that bulk changes leave it alone.
+func ActiveSince(project Project, t time.Time) bool {
+	if n := len(project.History); n > 0 && project.History[n-1].At.After(t) {
+		return true
+	}
+
+	for _, head := range []string{
+		filepath.Join(project.Path, ".git", "logs", "HEAD"),
+		filepath.Join(project.Path, "repo", ".git", "logs", "HEAD"),
+	} {
+		if info, err := os.Stat(head); err == nil && info.ModTime().After(t) {
+			return true
+		}
+	}
+
+	active := false
+	seen := 0
+	filepath.WalkDir(project.Path, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() && path != project.Path && activitySkipDirs[d.Name()] {
+			return filepath.SkipDir
+		}
+
+		seen++
+		if seen > maxActivityFiles {
+			active = true
+			return filepath.SkipAll
+		}
+		if info, err := d.Info(); err == nil && info.ModTime().After(t) {
+			active = true
+			return filepath.SkipAll
+		}
+		return nil
+	})
+	return active
+}
+
+func SelectProjects(cfg *Config, sel Selector) ([]Project, error) {
+	var selected []Project
+	for _, project := range GetAllProjects(cfg) {
+		matched, err := sel.Match(cfg, project)
+		if err != nil {
+			return nil, err
+		}
+		if matched {
+			selected = append(selected, project)
+		}
+	}
+	sort.Slice(selected, func(i, j int) bool {
+		return selected[i].Name < selected[j].Name
+	})
+	return selected, nil
+}
+
+func WorkspaceOf(cfg *Config, project Project) string {
+	best := ""
+	for _, w := range cfg.Workspaces {
+		if (project.Path == w || strings.HasPrefix(project.Path, w+string(os.PathSeparator))) && len(w) > len(best) {
+			best = w
+		}
+	}
+	return best
+}
+
+func HasTag(project Project, tag string) bool {
+	for _, t := range project.Tags {
+		if strings.EqualFold(t, tag) {
+			return true
+		}
+	}
+	return false
+}
+
+// MatchesText reports whether text appears, ignoring case, in the name,
+// alias, description or one of the tags of a project.
+func MatchesText(project Project, text string) bool {
+	text = strings.ToLower(text)
+	fields := append([]string{project.Name, project.Alias, project.Description}, project.Tags...)
+	for _, f := range fields {
+		if strings.Contains(strings.ToLower(f), text) {
+			return true
+		}
+	}
+	return false
+}
+
+// AddTags adds the tags a project does not have yet.
+func AddTags(project *Project, tags ...string) {
+	for _, t := range tags {
+		if t != "" && !HasTag(*project, t) {
+			project.Tags = append(project.Tags, t)
+		}
+	}
+}
+
+// RemoveTags removes tags from a project, ignoring case.
+func RemoveTags(project *Project, tags ...string) {
+	var kept []string
+	for _, t := range project.Tags {
+		if !HasTag(Project{Tags: tags}, t) {
+			kept = append(kept, t)
+		}
+	}
+	project.Tags = kept
+}
@@ -0,0 +1,147 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestSelectorMatch(t *testing.T) {
+	cfg := &Config{Workspaces: []string{"/ws/side", "/ws/side/nested", "/ws/work"}}
+	project := Project{
+		Name:        "hack-api",
+		Alias:       "ha",
+		Path:        "/ws/side/nested/hack-api",
+		Status:      "active",
+		Tags:        []string{"Go", "web"},
+		Description: "JSON API for the dashboard",
+	}
+
+	tests := []struct {
+		name string
+		sel  Selector
+		want bool
+	}{
+		{"empty", Selector{}, true},
+		{"glob on name", Selector{Pattern: "hack-*"}, true},
+		{"glob on alias", Selector{Pattern: "h?"}, true},
+		{"glob miss", Selector{Pattern: "web-*"}, false},
+		{"workspace by base name", Selector{Workspace: "nested"}, true},
+		{"workspace by path", Selector{Workspace: "/ws/side/nested"}, true},
+		{"outer workspace is not the project's", Selector{Workspace: "side"}, false},
+		{"other workspace", Selector{Workspace: "work"}, false},
+		{"tag ignores case", Selector{Tag: "go"}, true},
+		{"missing tag", Selector{Tag: "cli"}, false},
+		{"status ignores case", Selector{Status: "Active"}, true},
+		{"other status", Selector{Status: "done"}, false},
+		{"search in description", Selector{Search: "dashboard"}, true},
+		{"search in tags", Selector{Search: "WEB"}, true},
+		{"search miss", Selector{Search: "mobile"}, false},
+		{"all must match", Selector{Pattern: "hack-*", Tag: "cli"}, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.sel.Match(cfg, project)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("Match = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSelectorBadPattern(t *testing.T) {
+	if _, err := (Selector{Pattern: "["}).Match(&Config{}, Project{Name: "x"}); err == nil {
+		t.Error("a malformed glob was accepted")
+	}
+}
+
+// staleProject returns a project whose whole tree was last touched a year
+// ago.
+func staleProject(t *testing.T, files ...string) Project {
+	t.Helper()
+	root := filepath.Join(t.TempDir(), "demo")
+	for _, f := range append(files, "repo/main.go") {
+		path := filepath.Join(root, f)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, nil, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	age(t, root, 365*24*time.Hour)
+	return Project{Name: "demo", Path: root}
+}
+
+// age sets the times of everything under root back by d.
+func age(t *testing.T, root string, d time.Duration) {
+	t.Helper()
+	old := time.Now().Add(-d)
+	var paths []string
+	filepath.Walk(root, func(path string, _ os.FileInfo, err error) error {
+		paths = append(paths, path)
+		return err
+	})
+	// Children first, so touching them does not bump their folders again.
+	for i := len(paths) - 1; i >= 0; i-- {
+		if err := os.Chtimes(paths[i], old, old); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func touch(t *testing.T, path string) {
+	t.Helper()
+	now := time.Now()
+	if err := os.Chtimes(path, now, now); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestActiveSince(t *testing.T) {
+	monthAgo := time.Now().Add(-30 * 24 * time.Hour)
+
+	t.Run("untouched", func(t *testing.T) {
+		if ActiveSince(staleProject(t), monthAgo) {
+			t.Error("a project untouched for a year counts as active")
+		}
+	})
+
+	t.Run("deep file edited", func(t *testing.T) {
+		p := staleProject(t, "repo/internal/pkg/file.go")
+		touch(t, filepath.Join(p.Path, "repo", "internal", "pkg", "file.go"))
+		if !ActiveSince(p, monthAgo) {
+			t.Error("an edit deep in the tree was missed")
+		}
+	})
+
+	t.Run("dependencies ignored", func(t *testing.T) {
+		p := staleProject(t, "repo/node_modules/left-pad/index.js")
+		touch(t, filepath.Join(p.Path, "repo", "node_modules", "left-pad", "index.js"))
+		touch(t, filepath.Join(p.Path, "repo", "node_modules", "left-pad"))
+		if ActiveSince(p, monthAgo) {
+			t.Error("an install in node_modules counts as activity")
+		}
+	})
+
+	t.Run("commit", func(t *testing.T) {
+		p := staleProject(t, "repo/.git/logs/HEAD")
+		touch(t, filepath.Join(p.Path, "repo", ".git", "logs", "HEAD"))
+		if !ActiveSince(p, monthAgo) {
+			t.Error("a recent commit was missed")
+		}
+	})
+
+	t.Run("status change", func(t *testing.T) {
+		p := staleProject(t)
+		p.History = []StatusChange{{To: "active", At: time.Now()}}
+		if !ActiveSince(p, monthAgo) {
+			t.Error("a recent status change was missed")
+		}
+	})
+}
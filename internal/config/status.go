@@ -0,0 +1,210 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// NotSet is the status shown for projects that have none.
+const NotSet = "not set"
+
+// Status is one step of the project workflow.
+type Status struct {
+	Name string `json:"name"`
+	// Color is a theme colour such as "success" or "muted", or any colour
+	// lipgloss accepts.
+	Color string `json:"color,omitempty"`
+	Icon  string `json:"icon,omitempty"`
+	// Next lists the statuses a project may move on to. Empty allows any.
+	Next []string `json:"next,omitempty"`
+}
+
+// Label is the status name with its icon in front, if it has one.
+func (s Status) Label() string {
+	if s.Icon == "" {
+		return s.Name
+	}
+	return s.Icon + " " + s.Name
+}
+
+// DefaultStatuses are used when the config does not list any.
+var DefaultStatuses = []Status{
+	{Name: "active", Color: "success"},
+	{Name: "archived", Color: "warning"},
+	{Name: "done", Color: "secondary"},
+	{Name: NotSet, Color: "muted"},
+}
+
+// Statuses returns the configured statuses, in workflow order.
+func Statuses(cfg *Config) []Status {
+	if cfg == nil || len(cfg.Statuses) == 0 {
+		return DefaultStatuses
+	}
+	return cfg.Statuses
+}
+
+// StatusNames returns the names of the configured statuses.
+func StatusNames(cfg *Config) []string {
+	var names []string
+	for _, s := range Statuses(cfg) {
+		names = append(names, s.Name)
+	}
+	return names
+}
+
+// StatusRank is the position of a status in the workflow, for sorting.
+// Statuses the config does not know come after every configured one.
+func StatusRank(cfg *Config, name string) int {
+	statuses := Statuses(cfg)
+	for i, s := range statuses {
+		if strings.EqualFold(s.Name, name) {
+			return i
+		}
+	}
+	return len(statuses)
+}
+
+// LookupStatus finds a status by name, ignoring case.
+func LookupStatus(cfg *Config, name string) (Status, bool) {
+	for _, s := range Statuses(cfg) {
+		if strings.EqualFold(s.Name, name) {
+			return s, true
+		}
+	}
+	return Status{}, false
+}
+
+// StatusOf returns the status of a project, or NotSet.
+func StatusOf(project Project) string {
+	if project.Status == "" {
+		return NotSet
+	}
+	return project.Status
+}
+
+// StatusChange records a project moving from one status to another.
+type StatusChange struct {
+	From string    `json:"from,omitempty"`
+	To   string    `json:"to"`
+	At   time.Time `json:"at"`
+	Note string    `json:"note,omitempty"`
+}
+
+// ChangeStatus sets the status of a project and records the change in its
+// history. Setting the status a project already has changes nothing.
+func ChangeStatus(project *Project, status, note string) {
+	if status == StatusOf(*project) {
+		return
+	}
+	project.History = append(project.History, StatusChange{
+		From: project.Status,
+		To:   status,
+		At:   time.Now(),
+		Note: note,
+	})
+	project.Status = status
+}
+
+// StatusSpan is a stretch of time a project spent in one status.
+type StatusSpan struct {
+	Status string
+	Start  time.Time
+	End    time.Time
+	// Next is the status the project moved on to; empty for the current one.
+	Next string
+}
+
+// Duration is how long the span lasted.
+func (s StatusSpan) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// StatusSpans splits the history of a project into the time spent in each
+// status. The last span runs until now. Time before the first recorded change
+// is unknown and left out.
+func StatusSpans(project Project, now time.Time) []StatusSpan {
+	spans := make([]StatusSpan, len(project.History))
+	for i, c := range project.History {
+		spans[i] = StatusSpan{Status: c.To, Start: c.At, End: now}
+		if i+1 < len(project.History) {
+			next := project.History[i+1]
+			spans[i].End = next.At
+			spans[i].Next = next.To
+		}
+	}
+	return spans
+}
+
+// InitialStatus is the status given to new projects: "initial_status" from
+// the config, or else the first status.
+func InitialStatus(cfg *Config) string {
+	if cfg != nil && cfg.InitialStatus != "" {
+		return cfg.InitialStatus
+	}
+	return Statuses(cfg)[0].Name
+}
+
+// NextStatuses lists the statuses a project in status from may move to.
+func NextStatuses(cfg *Config, from string) []string {
+	var next []string
+	for _, s := range Statuses(cfg) {
+		if s.Name != from && CheckTransition(cfg, from, s.Name) == nil {
+			next = append(next, s.Name)
+		}
+	}
+	return next
+}
+
+// CheckTransition reports why a project may not move from one status to
+// another. Statuses that are not configured, such as a project that has none
+// yet, may move anywhere.
+func CheckTransition(cfg *Config, from, to string) error {
+	if _, ok := LookupStatus(cfg, to); !ok {
+		return fmt.Errorf("unknown status '%s'. Valid: %s", to, strings.Join(StatusNames(cfg), ", "))
+	}
+	if from == "" {
+		from = NotSet
+	}
+	current, ok := LookupStatus(cfg, from)
+	if !ok || len(current.Next) == 0 || strings.EqualFold(from, to) {
+		return nil
+	}
+	for _, n := range current.Next {
+		if strings.EqualFold(n, to) {
+			return nil
+		}
+	}
+	return fmt.Errorf("cannot go from '%s' to '%s'. Allowed: %s", from, to, strings.Join(current.Next, ", "))
+}
+
+// ValidateStatuses reports duplicate statuses and references to statuses
+// that do not exist.
+func ValidateStatuses(cfg *Config) error {
+	var errs []error
+	seen := make(map[string]bool)
+	for _, s := range Statuses(cfg) {
+		key := strings.ToLower(s.Name)
+		switch {
+		case s.Name == "":
+			errs = append(errs, errors.New("a status has no name"))
+		case seen[key]:
+			errs = append(errs, fmt.Errorf("status '%s' is defined twice", s.Name))
+		}
+		seen[key] = true
+	}
+
+	for _, s := range Statuses(cfg) {
+		for _, n := range s.Next {
+			if !seen[strings.ToLower(n)] {
+				errs = append(errs, fmt.Errorf("status '%s' leads to unknown status '%s'", s.Name, n))
+			}
+		}
+	}
+
+	if cfg != nil && cfg.InitialStatus != "" && !seen[strings.ToLower(cfg.InitialStatus)] {
+		errs = append(errs, fmt.Errorf("initial status '%s' is not a status", cfg.InitialStatus))
+	}
+	return errors.Join(errs...)
+}
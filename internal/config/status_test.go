@@ -0,0 +1,128 @@
+package config
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"time"
+)
+
+func workflowConfig() *Config {
+	return &Config{Statuses: []Status{
+		{Name: "idea", Next: []string{"active"}},
+		{Name: "active", Next: []string{"paused", "done"}},
+		{Name: "paused", Next: []string{"active"}},
+		{Name: "done"},
+	}}
+}
+
+func TestCheckTransition(t *testing.T) {
+	cfg := workflowConfig()
+	tests := []struct {
+		from, to string
+		ok       bool
+	}{
+		{"idea", "active", true},
+		{"idea", "done", false},
+		{"active", "Paused", true},
+		{"paused", "done", false},
+		{"paused", "paused", true},
+		{"done", "idea", true},
+		{"", "done", true},
+		{"legacy", "idea", true},
+		{"active", "archived", false},
+	}
+
+	for _, tt := range tests {
+		err := CheckTransition(cfg, tt.from, tt.to)
+		if (err == nil) != tt.ok {
+			t.Errorf("CheckTransition(%q, %q) = %v, want ok=%v", tt.from, tt.to, err, tt.ok)
+		}
+	}
+}
+
+func TestDefaultStatuses(t *testing.T) {
+	if err := CheckTransition(&Config{}, "active", "archived"); err != nil {
+		t.Errorf("the default statuses refuse active → archived: %v", err)
+	}
+	if got := InitialStatus(&Config{}); got != "active" {
+		t.Errorf("InitialStatus = %q, want active", got)
+	}
+}
+
+func TestNextStatuses(t *testing.T) {
+	if got := NextStatuses(workflowConfig(), "active"); !slices.Equal(got, []string{"paused", "done"}) {
+		t.Errorf("NextStatuses(active) = %v", got)
+	}
+}
+
+func TestStatusRank(t *testing.T) {
+	cfg := workflowConfig()
+	names := []string{"legacy", "done", "Active", "paused", "idea"}
+	slices.SortFunc(names, func(a, b string) int { return StatusRank(cfg, a) - StatusRank(cfg, b) })
+	if want := []string{"idea", "Active", "paused", "done", "legacy"}; !slices.Equal(names, want) {
+		t.Errorf("sorted by rank = %v, want %v", names, want)
+	}
+}
+
+func TestValidateStatuses(t *testing.T) {
+	if err := ValidateStatuses(workflowConfig()); err != nil {
+		t.Errorf("a valid workflow was rejected: %v", err)
+	}
+
+	cfg := workflowConfig()
+	cfg.Statuses = append(cfg.Statuses, Status{Name: "Done"}, Status{Name: "stuck", Next: []string{"gone"}})
+	cfg.InitialStatus = "draft"
+	err := ValidateStatuses(cfg)
+	if err == nil {
+		t.Fatal("duplicate and unknown statuses were accepted")
+	}
+	for _, want := range []string{"'Done' is defined twice", "unknown status 'gone'", "initial status 'draft'"} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("error %q does not mention %q", err, want)
+		}
+	}
+}
+
+func TestChangeStatus(t *testing.T) {
+	p := Project{Name: "demo"}
+	ChangeStatus(&p, "active", "")
+	ChangeStatus(&p, "done", "shipped")
+	ChangeStatus(&p, "done", "")
+
+	if p.Status != "done" {
+		t.Errorf("Status = %q, want done", p.Status)
+	}
+	if len(p.History) != 2 {
+		t.Fatalf("History has %d entries, want 2: %+v", len(p.History), p.History)
+	}
+	if c := p.History[1]; c.From != "active" || c.To != "done" || c.Note != "shipped" {
+		t.Errorf("last change = %+v", c)
+	}
+
+	unset := Project{Name: "fresh"}
+	ChangeStatus(&unset, NotSet, "")
+	if len(unset.History) != 0 {
+		t.Error("setting a project without status to 'not set' was recorded")
+	}
+}
+
+func TestStatusSpans(t *testing.T) {
+	start := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	p := Project{History: []StatusChange{
+		{To: "active", At: start},
+		{From: "active", To: "archived", At: start.Add(48 * time.Hour)},
+	}}
+	now := start.Add(72 * time.Hour)
+
+	spans := StatusSpans(p, now)
+	if len(spans) != 2 {
+		t.Fatalf("got %d spans, want 2", len(spans))
+	}
+	if spans[0].Status != "active" || spans[0].Next != "archived" || spans[0].Duration() != 48*time.Hour {
+		t.Errorf("first span = %+v", spans[0])
+	}
+	if spans[1].Next != "" || spans[1].Duration() != 24*time.Hour {
+		t.Errorf("current span = %+v", spans[1])
+	}
+}
@@ -0,0 +1,156 @@
+// Package frecency ranks project directories by how often and how recently
+// they were visited, the way zoxide ranks directories.
+package frecency
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// maxRank is the total rank above which every entry is aged, so old
+// favourites slowly make room for new ones.
+const maxRank = 1000
+
+// Entry is the visit record of one directory.
+type Entry struct {
+	Path      string    `json:"path"`
+	Rank      float64   `json:"rank"`
+	LastVisit time.Time `json:"last_visit"`
+}
+
+// Score weighs the rank by how long ago the last visit was.
+func (e Entry) Score(now time.Time) float64 {
+	age := now.Sub(e.LastVisit)
+	switch {
+	case age < time.Hour:
+		return e.Rank * 4
+	case age < 24*time.Hour:
+		return e.Rank * 2
+	case age < 7*24*time.Hour:
+		return e.Rank / 2
+	}
+	return e.Rank / 4
+}
+
+func getDBPath() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "frecency.json"), nil
+}
+
+// Load returns the visit records by path. A missing file means no visits.
+func Load() (map[string]Entry, error) {
+	dbPath, err := getDBPath()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(dbPath)
+	if os.IsNotExist(err) {
+		return map[string]Entry{}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []Entry
+	if err := json.Unmarshal(data, &entries); err != nil {
+		return nil, err
+	}
+
+	byPath := make(map[string]Entry, len(entries))
+	for _, e := range entries {
+		byPath[e.Path] = e
+	}
+	return byPath, nil
+}
+
+func save(byPath map[string]Entry) error {
+	dbPath, err := getDBPath()
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(dbPath), 0755); err != nil {
+		return err
+	}
+
+	entries := make([]Entry, 0, len(byPath))
+	for _, e := range byPath {
+		entries = append(entries, e)
+	}
+	sort.Slice(entries, func(i, j int) bool { return entries[i].Path < entries[j].Path })
+
+	data, err := json.MarshalIndent(entries, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(dbPath), "frecency-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), dbPath)
+}
+
+// Visit records a visit to path. When the ranks add up to more than maxRank
+// they are all scaled down and entries that fall below one are forgotten.
+func Visit(path string) error {
+	byPath, err := Load()
+	if err != nil {
+		return err
+	}
+
+	e := byPath[path]
+	e.Path = path
+	e.Rank++
+	e.LastVisit = time.Now()
+	byPath[path] = e
+
+	total := 0.0
+	for _, e := range byPath {
+		total += e.Rank
+	}
+	if total > maxRank {
+		for p, e := range byPath {
+			e.Rank *= 0.9
+			if e.Rank < 1 {
+				delete(byPath, p)
+				continue
+			}
+			byPath[p] = e
+		}
+	}
+
+	return save(byPath)
+}
+
+// Scores returns the current score of every visited path.
+func Scores() (map[string]float64, error) {
+	byPath, err := Load()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	scores := make(map[string]float64, len(byPath))
+	for p, e := range byPath {
+		scores[p] = e.Score(now)
+	}
+	return scores, nil
+}
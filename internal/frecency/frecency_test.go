@@ -0,0 +1,91 @@
+package frecency
+
+import (
+	"testing"
+	"time"
+)
+
+func TestScore(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		age  time.Duration
+		want float64
+	}{
+		{10 * time.Minute, 40},
+		{5 * time.Hour, 20},
+		{3 * 24 * time.Hour, 5},
+		{30 * 24 * time.Hour, 2.5},
+	}
+	for _, tt := range tests {
+		e := Entry{Rank: 10, LastVisit: now.Add(-tt.age)}
+		if got := e.Score(now); got != tt.want {
+			t.Errorf("Score after %v = %v, want %v", tt.age, got, tt.want)
+		}
+	}
+}
+
+func TestVisit(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	for range 3 {
+		if err := Visit("/ws/api"); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := Visit("/ws/web"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := entries["/ws/api"].Rank; got != 3 {
+		t.Errorf("rank of /ws/api = %v, want 3", got)
+	}
+
+	scores, err := Scores()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if scores["/ws/api"] <= scores["/ws/web"] {
+		t.Errorf("the more visited project scores lower: %v", scores)
+	}
+}
+
+func TestVisitAges(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	if err := save(map[string]Entry{
+		"/ws/old":  {Path: "/ws/old", Rank: 1.05, LastVisit: time.Now().Add(-90 * 24 * time.Hour)},
+		"/ws/busy": {Path: "/ws/busy", Rank: maxRank, LastVisit: time.Now()},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := Visit("/ws/busy"); err != nil {
+		t.Fatal(err)
+	}
+
+	entries, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := entries["/ws/old"]; ok {
+		t.Error("an entry aged below a rank of one was kept")
+	}
+	if got, want := entries["/ws/busy"].Rank, (maxRank+1)*0.9; got != want {
+		t.Errorf("rank of /ws/busy = %v, want %v", got, want)
+	}
+}
+
+func TestLoadMissing(t *testing.T) {
+	t.Setenv("HOME", t.TempDir())
+
+	entries, err := Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 0 {
+		t.Errorf("Load without a file = %v", entries)
+	}
+}
@@ -0,0 +1,134 @@
+package hooks
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/projectfile"
+)
+
+const (
+	EventCreate  = "create"
+	EventStatus  = "status"
+	EventOpen    = "open"
+	EventDelete  = "delete"
+	EventClone   = "clone"
+	EventRestore = "restore"
+)
+
+var (
+	stdout io.Writer = os.Stdout
+	stderr io.Writer = os.Stderr
+)
+
+// SetOutput redirects hook output, e.g. while a full-screen TUI owns the
+// terminal. A nil writer restores stdout and stderr.
+func SetOutput(w io.Writer) {
+	if w == nil {
+		stdout, stderr = os.Stdout, os.Stderr
+		return
+	}
+	stdout, stderr = w, w
+}
+
+type Event struct {
+	Name           string `json:"event"`
+	Phase          string `json:"phase"`
+	Project        string `json:"project,omitempty"`
+	Path           string `json:"path,omitempty"`
+	Workspace      string `json:"workspace,omitempty"`
+	Status         string `json:"status,omitempty"`
+	PreviousStatus string `json:"previous_status,omitempty"`
+	URL            string `json:"url,omitempty"`
+	Tool           string `json:"tool,omitempty"`
+}
+
+type VetoError struct {
+	Hook string
+	Err  error
+}
+
+func (e *VetoError) Error() string {
+	return fmt.Sprintf("%s vetoed by hook '%s': %v", e.Hook[len("pre-"):], e.Hook, e.Err)
+}
+
+func (e *VetoError) Unwrap() error {
+	return e.Err
+}
+
+func Pre(cfg *config.Config, ev Event) error {
+	ev.Phase = "pre"
+	name := "pre-" + ev.Name
+	for _, command := range commandsFor(cfg, ev, name) {
+		if err := run(command, ev); err != nil {
+			return &VetoError{Hook: name, Err: fmt.Errorf("%s: %w", command, err)}
+		}
+	}
+	return nil
+}
+
+func Post(cfg *config.Config, ev Event) error {
+	ev.Phase = "post"
+	name := "post-" + ev.Name
+	var firstErr error
+	for _, command := range commandsFor(cfg, ev, name) {
+		if err := run(command, ev); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("hook '%s' failed: %s: %w", name, command, err)
+		}
+	}
+	return firstErr
+}
+
+func commandsFor(cfg *config.Config, ev Event, name string) []string {
+	var commands []string
+	if cfg != nil {
+		commands = append(commands, cfg.Hooks[name]...)
+	}
+
+	if ev.Path != "" {
+		if f, err := projectfile.Load(ev.Path); err == nil {
+			commands = append(commands, f.Hooks[name]...)
+		}
+	}
+
+	return commands
+}
+
+func run(command string, ev Event) error {
+	payload, err := json.Marshal(ev)
+	if err != nil {
+		return err
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	cmd := exec.Command(shell, "-c", command)
+	cmd.Stdin = bytes.NewReader(payload)
+	cmd.Stdout = stdout
+	cmd.Stderr = stderr
+	cmd.Env = append(os.Environ(),
+		"ORBIT_EVENT="+ev.Name,
+		"ORBIT_PHASE="+ev.Phase,
+		"ORBIT_PROJECT="+ev.Project,
+		"ORBIT_PROJECT_PATH="+ev.Path,
+		"ORBIT_WORKSPACE="+ev.Workspace,
+		"ORBIT_STATUS="+ev.Status,
+		"ORBIT_PREVIOUS_STATUS="+ev.PreviousStatus,
+		"ORBIT_URL="+ev.URL,
+		"ORBIT_TOOL="+ev.Tool,
+	)
+
+	if info, err := os.Stat(ev.Path); err == nil && info.IsDir() {
+		cmd.Dir = ev.Path
+	}
+
+	return cmd.Run()
+}
@@ -0,0 +1,108 @@
+// Package journal keeps dated notes on a project in a markdown file in its
+// docs folder, so they travel with the project and read well anywhere.
+package journal
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+)
+
+// timeLayout is the format of the heading that starts each entry.
+const timeLayout = "2006-01-02 15:04"
+
+// Entry is one note in a journal.
+type Entry struct {
+	Time time.Time
+	Text string
+}
+
+// Path is where the journal of the project at projectPath is kept.
+func Path(projectPath string) string {
+	return filepath.Join(projectPath, "docs", "journal.md")
+}
+
+// Append adds a note to the end of the journal, creating it if needed. The
+// project directory itself must exist; it is never created here.
+func Append(projectPath, text string, at time.Time) error {
+	info, err := os.Stat(projectPath)
+	if err != nil {
+		return err
+	}
+	if !info.IsDir() {
+		return fmt.Errorf("%s is not a directory", projectPath)
+	}
+
+	journalPath := Path(projectPath)
+	if err := os.MkdirAll(filepath.Dir(journalPath), 0755); err != nil {
+		return err
+	}
+
+	var entry strings.Builder
+	if _, err := os.Stat(journalPath); os.IsNotExist(err) {
+		entry.WriteString("# Journal\n")
+	}
+	fmt.Fprintf(&entry, "\n## %s\n\n%s\n", at.Local().Format(timeLayout), strings.TrimSpace(text))
+
+	f, err := os.OpenFile(journalPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.WriteString(entry.String()); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// Read returns the entries of the journal in the order they were written.
+// A missing journal has no entries. Anything before the first dated heading,
+// such as the title, is skipped, so the file can be edited by hand.
+func Read(projectPath string) ([]Entry, error) {
+	f, err := os.Open(Path(projectPath))
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var entries []Entry
+	var text []string
+	flush := func() {
+		if len(entries) > 0 {
+			entries[len(entries)-1].Text = strings.TrimSpace(strings.Join(text, "\n"))
+		}
+		text = nil
+	}
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if heading, ok := strings.CutPrefix(line, "## "); ok {
+			if at, err := time.ParseInLocation(timeLayout, strings.TrimSpace(heading), time.Local); err == nil {
+				flush()
+				entries = append(entries, Entry{Time: at})
+				continue
+			}
+		}
+		text = append(text, line)
+	}
+	flush()
+	return entries, scanner.Err()
+}
+
+// Since returns the entries written at or after t.
+func Since(entries []Entry, t time.Time) []Entry {
+	var recent []Entry
+	for _, e := range entries {
+		if !e.Time.Before(t) {
+			recent = append(recent, e)
+		}
+	}
+	return recent
+}
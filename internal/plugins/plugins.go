@@ -0,0 +1,135 @@
+package plugins
+
+import (
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+const Prefix = "orbit-"
+
+type MenuEntry struct {
+	Label string   `json:"label"`
+	Args  []string `json:"args,omitempty"`
+}
+
+type Manifest struct {
+	Description string      `json:"description,omitempty"`
+	Menu        []MenuEntry `json:"menu,omitempty"`
+}
+
+type Plugin struct {
+	Name     string
+	Path     string
+	Manifest Manifest
+}
+
+func Dir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(configDir, "plugins"), nil
+}
+
+func searchDirs() []string {
+	var dirs []string
+	if dir, err := Dir(); err == nil {
+		dirs = append(dirs, dir)
+	}
+	return append(dirs, filepath.SplitList(os.Getenv("PATH"))...)
+}
+
+func Find(name string) (*Plugin, bool) {
+	if name == "" || strings.ContainsAny(name, `/\`) {
+		return nil, false
+	}
+
+	for _, dir := range searchDirs() {
+		path := filepath.Join(dir, Prefix+name)
+		if isExecutable(path) {
+			return load(name, path), true
+		}
+	}
+	return nil, false
+}
+
+func List() []Plugin {
+	seen := make(map[string]bool)
+	var plugins []Plugin
+
+	for _, dir := range searchDirs() {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			continue
+		}
+		for _, entry := range entries {
+			name, ok := strings.CutPrefix(entry.Name(), Prefix)
+			if !ok || name == "" || seen[name] || strings.HasSuffix(name, ".json") {
+				continue
+			}
+			path := filepath.Join(dir, entry.Name())
+			if !isExecutable(path) {
+				continue
+			}
+			seen[name] = true
+			plugins = append(plugins, *load(name, path))
+		}
+	}
+
+	sort.Slice(plugins, func(i, j int) bool {
+		return plugins[i].Name < plugins[j].Name
+	})
+	return plugins
+}
+
+func load(name, path string) *Plugin {
+	p := &Plugin{Name: name, Path: path}
+
+	data, err := os.ReadFile(path + ".json")
+	if err == nil {
+		json.Unmarshal(data, &p.Manifest)
+	}
+
+	return p
+}
+
+func (p Plugin) Command(args []string, project *config.Project, cfg *config.Config) *exec.Cmd {
+	cmd := exec.Command(p.Path, args...)
+	cmd.Env = append(os.Environ(), Env(cfg, project)...)
+	return cmd
+}
+
+func Env(cfg *config.Config, project *config.Project) []string {
+	var env []string
+
+	if configPath, err := config.Path(); err == nil {
+		env = append(env, "ORBIT_CONFIG="+configPath)
+	}
+	if exe, err := os.Executable(); err == nil {
+		env = append(env, "ORBIT_BIN="+exe)
+	}
+
+	if project != nil {
+		env = append(env,
+			"ORBIT_PROJECT="+project.Name,
+			"ORBIT_PROJECT_PATH="+project.Path,
+			"ORBIT_STATUS="+project.Status,
+		)
+		if cfg != nil {
+			env = append(env, "ORBIT_WORKSPACE="+config.WorkspaceOf(cfg, *project))
+		}
+	}
+
+	return env
+}
+
+func isExecutable(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir() && info.Mode()&0111 != 0
+}
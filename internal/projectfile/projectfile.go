@@ -0,0 +1,54 @@
+package projectfile
+
+import (
+	"encoding/json"
+	"os"
+	"path/filepath"
+)
+
+const FileName = "orbit.json"
+
+type Task struct {
+	Cmd         string            `json:"cmd"`
+	Dir         string            `json:"dir,omitempty"`
+	Env         map[string]string `json:"env,omitempty"`
+	Description string            `json:"description,omitempty"`
+}
+
+type File struct {
+	Tasks map[string]Task     `json:"tasks,omitempty"`
+	Hooks map[string][]string `json:"hooks,omitempty"`
+}
+
+func Path(projectPath string) string {
+	return filepath.Join(projectPath, FileName)
+}
+
+func Load(projectPath string) (*File, error) {
+	data, err := os.ReadFile(Path(projectPath))
+	if os.IsNotExist(err) {
+		return &File{Tasks: make(map[string]Task)}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var f File
+	if err := json.Unmarshal(data, &f); err != nil {
+		return nil, err
+	}
+
+	if f.Tasks == nil {
+		f.Tasks = make(map[string]Task)
+	}
+
+	return &f, nil
+}
+
+func Save(projectPath string, f *File) error {
+	data, err := json.MarshalIndent(f, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(Path(projectPath), data, 0644)
+}
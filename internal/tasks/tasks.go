@@ -0,0 +1,212 @@
+package tasks
+
+import (
+	"bufio"
+	"encoding/json"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/henrynguci/orbit/internal/projectfile"
+)
+
+type Task struct {
+	projectfile.Task
+	Name     string
+	Detected bool
+}
+
+func Resolve(projectPath string) ([]Task, error) {
+	f, err := projectfile.Load(projectPath)
+	if err != nil {
+		return nil, err
+	}
+
+	byName := Detect(filepath.Join(projectPath, "repo"))
+	for name, t := range f.Tasks {
+		byName[name] = Task{Task: t, Name: name}
+	}
+
+	tasks := make([]Task, 0, len(byName))
+	for _, t := range byName {
+		tasks = append(tasks, t)
+	}
+	sort.Slice(tasks, func(i, j int) bool {
+		return tasks[i].Name < tasks[j].Name
+	})
+
+	return tasks, nil
+}
+
+func Lookup(tasks []Task, name string) (Task, bool) {
+	for _, t := range tasks {
+		if t.Name == name {
+			return t, true
+		}
+	}
+	return Task{}, false
+}
+
+func Detect(repoDir string) map[string]Task {
+	tasks := make(map[string]Task)
+	add := func(name, cmd string) {
+		if _, exists := tasks[name]; !exists {
+			tasks[name] = Task{Task: projectfile.Task{Cmd: cmd}, Name: name, Detected: true}
+		}
+	}
+
+	if fileExists(filepath.Join(repoDir, "Makefile")) {
+		for _, target := range makeTargets(filepath.Join(repoDir, "Makefile")) {
+			add(target, "make "+target)
+		}
+	}
+
+	if fileExists(filepath.Join(repoDir, "go.mod")) {
+		add("build", "go build ./...")
+		add("test", "go test ./...")
+		add("vet", "go vet ./...")
+		add("tidy", "go mod tidy")
+		add("run", "go run .")
+	}
+
+	if fileExists(filepath.Join(repoDir, "package.json")) {
+		runner := "npm run"
+		switch {
+		case fileExists(filepath.Join(repoDir, "pnpm-lock.yaml")):
+			runner = "pnpm run"
+		case fileExists(filepath.Join(repoDir, "yarn.lock")):
+			runner = "yarn run"
+		case fileExists(filepath.Join(repoDir, "bun.lockb")):
+			runner = "bun run"
+		}
+		for _, script := range packageScripts(filepath.Join(repoDir, "package.json")) {
+			add(script, runner+" "+script)
+		}
+		add("install", strings.Fields(runner)[0]+" install")
+	}
+
+	if fileExists(filepath.Join(repoDir, "pyproject.toml")) || fileExists(filepath.Join(repoDir, "requirements.txt")) {
+		if fileExists(filepath.Join(repoDir, "requirements.txt")) {
+			add("install", "pip install -r requirements.txt")
+		} else {
+			add("install", "pip install -e .")
+		}
+		add("test", "python -m pytest")
+		for _, entry := range []string{"main.py", "app.py", "manage.py"} {
+			if fileExists(filepath.Join(repoDir, entry)) {
+				add("run", "python "+entry)
+				break
+			}
+		}
+	}
+
+	return tasks
+}
+
+var makeTargetRe = regexp.MustCompile(`^([A-Za-z0-9][A-Za-z0-9_.-]*)\s*:([^=]|$)`)
+
+func makeTargets(makefile string) []string {
+	f, err := os.Open(makefile)
+	if err != nil {
+		return nil
+	}
+	defer f.Close()
+
+	var targets []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		if m := makeTargetRe.FindStringSubmatch(scanner.Text()); m != nil {
+			targets = append(targets, m[1])
+		}
+	}
+	return targets
+}
+
+func packageScripts(packageJSON string) []string {
+	data, err := os.ReadFile(packageJSON)
+	if err != nil {
+		return nil
+	}
+
+	var pkg struct {
+		Scripts map[string]string `json:"scripts"`
+	}
+	if err := json.Unmarshal(data, &pkg); err != nil {
+		return nil
+	}
+
+	var scripts []string
+	for name := range pkg.Scripts {
+		scripts = append(scripts, name)
+	}
+	return scripts
+}
+
+func Command(projectPath string, task Task) *exec.Cmd {
+	dir := task.Dir
+	if dir == "" {
+		dir = "repo"
+	}
+	if !filepath.IsAbs(dir) {
+		dir = filepath.Join(projectPath, dir)
+	}
+
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/sh"
+	}
+
+	cmd := exec.Command(shell, "-c", task.Cmd)
+	cmd.Dir = dir
+	cmd.Env = os.Environ()
+
+	envFile := filepath.Join(projectPath, "secret", ".env")
+	for key, value := range LoadEnvFile(envFile) {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+	for key, value := range task.Env {
+		cmd.Env = append(cmd.Env, key+"="+value)
+	}
+
+	return cmd
+}
+
+func LoadEnvFile(path string) map[string]string {
+	env := make(map[string]string)
+
+	f, err := os.Open(path)
+	if err != nil {
+		return env
+	}
+	defer f.Close()
+
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			continue
+		}
+		key = strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+			value = value[1 : len(value)-1]
+		}
+		env[key] = value
+	}
+
+	return env
+}
+
+func fileExists(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && !info.IsDir()
+}
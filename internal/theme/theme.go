@@ -0,0 +1,272 @@
+// Package theme holds the colours and borders used by both the CLI output
+// and the TUI. Packages that build lipgloss styles register with OnChange and
+// rebuild them whenever a theme is applied.
+package theme
+
+import (
+	"fmt"
+	"os"
+	"sort"
+
+	"github.com/charmbracelet/lipgloss"
+	"github.com/muesli/termenv"
+)
+
+const (
+	Dark  = "dark"
+	Light = "light"
+)
+
+// Palette is the set of colours a theme is made of. Values are anything
+// lipgloss.Color accepts: "#RRGGBB" or an ANSI colour number.
+type Palette struct {
+	Primary   string `json:"primary,omitempty"`
+	Secondary string `json:"secondary,omitempty"`
+	Success   string `json:"success,omitempty"`
+	Warning   string `json:"warning,omitempty"`
+	Error     string `json:"error,omitempty"`
+	Info      string `json:"info,omitempty"`
+	Muted     string `json:"muted,omitempty"`
+	// Text is regular text; Highlight is text drawn on the primary colour.
+	Text      string `json:"text,omitempty"`
+	Highlight string `json:"highlight,omitempty"`
+	// Accent is used for the banner.
+	Accent string `json:"accent,omitempty"`
+	// Surface is the background of inactive buttons.
+	Surface string `json:"surface,omitempty"`
+
+	ButtonText string `json:"button_text,omitempty"`
+	Green      string `json:"green,omitempty"`
+	Red        string `json:"red,omitempty"`
+	Blue       string `json:"blue,omitempty"`
+	Purple     string `json:"purple,omitempty"`
+	Yellow     string `json:"yellow,omitempty"`
+}
+
+// Custom is a user theme from the config: "base", the built-in theme it
+// starts from, and the palette entries that differ from it, such as
+// "primary", mapped to colours. It is a plain map so the config can hold it
+// without depending on this package.
+type Custom = map[string]string
+
+// Theme is a resolved palette plus the display options that go with it.
+type Theme struct {
+	Name    string
+	Palette Palette
+	// ASCII draws borders with plain ASCII characters.
+	ASCII bool
+	// NoColor is set when the NO_COLOR environment variable is present.
+	// Colours are then dropped and highlights use reverse video.
+	NoColor bool
+}
+
+var builtin = map[string]Palette{
+	Dark: {
+		Primary:    "#7C3AED",
+		Secondary:  "#06B6D4",
+		Success:    "#10B981",
+		Warning:    "#F59E0B",
+		Error:      "#EF4444",
+		Info:       "#3B82F6",
+		Muted:      "#6B7280",
+		Text:       "#FFFFFF",
+		Highlight:  "#FFFFFF",
+		Accent:     "#E06C75",
+		Surface:    "#374151",
+		ButtonText: "#282C34",
+		Green:      "#98C379",
+		Red:        "#E06C75",
+		Blue:       "#61AFEF",
+		Purple:     "#C678DD",
+		Yellow:     "#F59E0B",
+	},
+	Light: {
+		Primary:    "#6D28D9",
+		Secondary:  "#0E7490",
+		Success:    "#15803D",
+		Warning:    "#B45309",
+		Error:      "#B91C1C",
+		Info:       "#1D4ED8",
+		Muted:      "#6B7280",
+		Text:       "#111827",
+		Highlight:  "#FFFFFF",
+		Accent:     "#BE123C",
+		Surface:    "#D1D5DB",
+		ButtonText: "#FFFFFF",
+		Green:      "#15803D",
+		Red:        "#B91C1C",
+		Blue:       "#1D4ED8",
+		Purple:     "#7E22CE",
+		Yellow:     "#B45309",
+	},
+}
+
+var (
+	current   = newTheme(Dark, builtin[Dark], false)
+	listeners []func()
+)
+
+func newTheme(name string, palette Palette, ascii bool) Theme {
+	t := Theme{Name: name, Palette: palette, ASCII: ascii, NoColor: os.Getenv("NO_COLOR") != ""}
+	if t.NoColor {
+		// termenv turns NO_COLOR into a profile without any attributes at
+		// all. Colours are dropped by Color instead, so keep the terminal's
+		// own profile for bold and reverse video.
+		lipgloss.SetColorProfile(termenv.NewOutput(os.Stdout).ColorProfile())
+	}
+	return t
+}
+
+// Current returns the theme in use.
+func Current() Theme {
+	return current
+}
+
+// OnChange registers fn to run every time a theme is applied.
+func OnChange(fn func()) {
+	listeners = append(listeners, fn)
+}
+
+// Apply switches to the theme called name, looking in custom before the
+// built-in themes. An empty name means the dark theme. On error the current
+// theme is kept.
+func Apply(name string, custom map[string]Custom, ascii bool) error {
+	palette, err := Resolve(name, custom)
+	if err != nil {
+		return err
+	}
+	if name == "" {
+		name = Dark
+	}
+
+	current = newTheme(name, palette, ascii)
+	for _, fn := range listeners {
+		fn()
+	}
+	return nil
+}
+
+// Resolve returns the palette of a theme. Custom themes are laid over their
+// base, so they only need to list the colours they change.
+func Resolve(name string, custom map[string]Custom) (Palette, error) {
+	if name == "" {
+		name = Dark
+	}
+	if c, ok := custom[name]; ok {
+		base := c["base"]
+		if base == "" {
+			base = Dark
+		}
+		palette, ok := builtin[base]
+		if !ok {
+			return Palette{}, fmt.Errorf("theme '%s' is based on unknown theme '%s'", name, base)
+		}
+		return palette.with(c), nil
+	}
+	if palette, ok := builtin[name]; ok {
+		return palette, nil
+	}
+	return Palette{}, fmt.Errorf("unknown theme '%s'", name)
+}
+
+// Names lists the built-in themes followed by the custom ones, sorted.
+func Names(custom map[string]Custom) []string {
+	names := []string{Dark, Light}
+	var extra []string
+	for name := range custom {
+		if _, ok := builtin[name]; !ok {
+			extra = append(extra, name)
+		}
+	}
+	sort.Strings(extra)
+	return append(names, extra...)
+}
+
+// with returns the palette with the entries named in colors replaced.
+// Names that are not palette entries, such as "base", are ignored.
+func (p Palette) with(colors map[string]string) Palette {
+	entries := p.entries()
+	for name, c := range colors {
+		if dst, ok := entries[name]; ok && c != "" {
+			*dst = c
+		}
+	}
+	return p
+}
+
+// entries maps the names of the palette entries to their fields.
+func (p *Palette) entries() map[string]*string {
+	return map[string]*string{
+		"primary":     &p.Primary,
+		"secondary":   &p.Secondary,
+		"success":     &p.Success,
+		"warning":     &p.Warning,
+		"error":       &p.Error,
+		"info":        &p.Info,
+		"muted":       &p.Muted,
+		"text":        &p.Text,
+		"highlight":   &p.Highlight,
+		"accent":      &p.Accent,
+		"surface":     &p.Surface,
+		"button_text": &p.ButtonText,
+		"green":       &p.Green,
+		"red":         &p.Red,
+		"blue":        &p.Blue,
+		"purple":      &p.Purple,
+		"yellow":      &p.Yellow,
+	}
+}
+
+// Color converts a palette entry for use in a style. With NO_COLOR set it
+// is no colour at all.
+func Color(c string) lipgloss.TerminalColor {
+	if current.NoColor {
+		return lipgloss.NoColor{}
+	}
+	return lipgloss.Color(c)
+}
+
+// Lookup returns the colour of the palette entry called name, such as
+// "success" or "muted". Any other name is taken as a colour itself, and an
+// empty one is the text colour.
+func (p Palette) Lookup(name string) string {
+	if name == "" {
+		name = "text"
+	}
+	if c, ok := p.entries()[name]; ok {
+		return *c
+	}
+	return name
+}
+
+// Named returns the colour of the palette entry called name in the current
+// theme, as Lookup resolves it.
+func Named(name string) lipgloss.TerminalColor {
+	return Color(current.Palette.Lookup(name))
+}
+
+// Border returns the rounded border, or its ASCII stand-in.
+func Border() lipgloss.Border {
+	if current.ASCII {
+		return lipgloss.ASCIIBorder()
+	}
+	return lipgloss.RoundedBorder()
+}
+
+// Line returns the character used for horizontal rules.
+func Line() string {
+	if current.ASCII {
+		return "-"
+	}
+	return "─"
+}
+
+// Filled returns a style drawing fg on bg. Without colours it falls back to
+// reverse video so highlighted rows and buttons still stand out.
+func Filled(fg, bg string) lipgloss.Style {
+	style := lipgloss.NewStyle().Foreground(Color(fg)).Background(Color(bg))
+	if current.NoColor {
+		style = style.Reverse(true)
+	}
+	return style
+}
@@ -0,0 +1,268 @@
+// Package timelog records the time spent on projects as sessions. Finished
+// sessions are appended to a log; the running one, if any, is kept in a file
+// of its own so checking on it stays cheap.
+package timelog
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// IdleTimeout is how long an automatic session may go without activity
+// before it is considered to have ended at its last activity.
+const IdleTimeout = 15 * time.Minute
+
+// Session is a stretch of time spent on one project.
+type Session struct {
+	Project string    `json:"project"`
+	Path    string    `json:"path"`
+	Start   time.Time `json:"start"`
+	End     time.Time `json:"end,omitzero"`
+	// Auto is set for sessions started by goto, open or the shell hook
+	// rather than by "orbit start".
+	Auto bool `json:"auto,omitempty"`
+	// Last is the latest activity the shell hook saw in the project.
+	Last time.Time `json:"last,omitzero"`
+}
+
+// Duration is the length of the session, up to now while it is running.
+func (s Session) Duration(now time.Time) time.Duration {
+	end := s.End
+	if end.IsZero() {
+		end = now
+	}
+	return end.Sub(s.Start)
+}
+
+// Total is the time spent on one project.
+type Total struct {
+	Project  string
+	Path     string
+	Duration time.Duration
+}
+
+func getPaths() (logPath, currentPath string, err error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", "", err
+	}
+	if err := os.MkdirAll(configDir, 0755); err != nil {
+		return "", "", err
+	}
+	return filepath.Join(configDir, "sessions.jsonl"), filepath.Join(configDir, "session.json"), nil
+}
+
+// Current returns the running session, or nil when none is.
+func Current() (*Session, error) {
+	_, currentPath, err := getPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(currentPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var s Session
+	if err := json.Unmarshal(data, &s); err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+func writeCurrent(s Session) error {
+	_, currentPath, err := getPaths()
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(s)
+	if err != nil {
+		return err
+	}
+
+	tmp, err := os.CreateTemp(filepath.Dir(currentPath), "session-*.json")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(data); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), currentPath)
+}
+
+// Start begins a session on a project, finishing the running one first. If
+// the project already has the running session it is left as it is. It
+// returns the session that was finished, if any.
+func Start(project, path string, auto bool) (*Session, error) {
+	running, err := Current()
+	if err != nil {
+		return nil, err
+	}
+	if running != nil && running.Path == path {
+		return nil, nil
+	}
+
+	now := time.Now()
+	var stopped *Session
+	if running != nil {
+		if stopped, err = StopAt(now); err != nil {
+			return nil, err
+		}
+	}
+
+	s := Session{Project: project, Path: path, Start: now, Auto: auto}
+	if auto {
+		s.Last = now
+	}
+	return stopped, writeCurrent(s)
+}
+
+// Stop finishes the running session now. It returns nil when none was
+// running.
+func Stop() (*Session, error) {
+	return StopAt(time.Now())
+}
+
+// StopAt finishes the running session at end and appends it to the log.
+func StopAt(end time.Time) (*Session, error) {
+	running, err := Current()
+	if err != nil || running == nil {
+		return nil, err
+	}
+
+	logPath, currentPath, err := getPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	running.End = end
+	data, err := json.Marshal(running)
+	if err != nil {
+		return nil, err
+	}
+
+	f, err := os.OpenFile(logPath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.Write(append(data, '\n')); err != nil {
+		f.Close()
+		return nil, err
+	}
+	if err := f.Close(); err != nil {
+		return nil, err
+	}
+
+	if err := os.Remove(currentPath); err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return running, nil
+}
+
+// StopIdle finishes the running session at its last activity if it was
+// started automatically and has seen none for longer than IdleTimeout.
+// Manual sessions are left alone. It returns the session it stopped, if any.
+func StopIdle(now time.Time) (*Session, error) {
+	running, err := Current()
+	if err != nil || running == nil {
+		return nil, err
+	}
+	if !running.Auto || now.Sub(running.Last) <= IdleTimeout {
+		return nil, nil
+	}
+	return StopAt(running.Last)
+}
+
+// Touch records activity in the running session.
+func Touch(s Session) error {
+	s.Last = time.Now()
+	return writeCurrent(s)
+}
+
+// Sessions returns the finished sessions, oldest first.
+func Sessions() ([]Session, error) {
+	logPath, _, err := getPaths()
+	if err != nil {
+		return nil, err
+	}
+
+	data, err := os.ReadFile(logPath)
+	if os.IsNotExist(err) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	var sessions []Session
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var s Session
+		if err := json.Unmarshal(scanner.Bytes(), &s); err != nil {
+			continue
+		}
+		sessions = append(sessions, s)
+	}
+	return sessions, scanner.Err()
+}
+
+// Totals adds up the time spent on each project since a moment, counting
+// only the part of a session after it. A zero since counts everything. The
+// projects come back with the most time first.
+func Totals(sessions []Session, since, now time.Time) []Total {
+	byPath := make(map[string]*Total)
+	var order []*Total
+	for _, s := range sessions {
+		start, end := s.Start, s.End
+		if end.IsZero() {
+			end = now
+		}
+		if start.Before(since) {
+			start = since
+		}
+		if !end.After(start) {
+			continue
+		}
+
+		t, ok := byPath[s.Path]
+		if !ok {
+			t = &Total{Path: s.Path}
+			byPath[s.Path] = t
+			order = append(order, t)
+		}
+		t.Project = s.Project
+		t.Duration += end.Sub(start)
+	}
+
+	totals := make([]Total, len(order))
+	for i, t := range order {
+		totals[i] = *t
+	}
+	sort.SliceStable(totals, func(i, j int) bool { return totals[i].Duration > totals[j].Duration })
+	return totals
+}
+
+// WeekStart is midnight on the Monday of the week t falls in.
+func WeekStart(t time.Time) time.Time {
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
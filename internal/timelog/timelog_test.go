@@ -0,0 +1,148 @@
+package timelog
+
+import (
+	"testing"
+	"time"
+)
+
+func setup(t *testing.T) {
+	t.Helper()
+	t.Setenv("HOME", t.TempDir())
+}
+
+func TestStartStop(t *testing.T) {
+	setup(t)
+
+	if s, err := Current(); err != nil || s != nil {
+		t.Fatalf("Current() before any session = %v, %v", s, err)
+	}
+
+	if _, err := Start("api", "/ws/api", false); err != nil {
+		t.Fatal(err)
+	}
+	first, _ := Current()
+	if stopped, err := Start("api", "/ws/api", false); err != nil || stopped != nil {
+		t.Fatalf("restarting the running project stopped %v, %v", stopped, err)
+	}
+	if again, _ := Current(); !again.Start.Equal(first.Start) {
+		t.Error("restarting the running project started a new session")
+	}
+
+	stopped, err := Start("web", "/ws/web", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stopped == nil || stopped.Project != "api" || stopped.End.IsZero() {
+		t.Fatalf("switching projects stopped %+v", stopped)
+	}
+
+	if stopped, err := Stop(); err != nil || stopped == nil || stopped.Project != "web" {
+		t.Fatalf("Stop() = %+v, %v", stopped, err)
+	}
+	if stopped, err := Stop(); err != nil || stopped != nil {
+		t.Fatalf("Stop() with nothing running = %+v, %v", stopped, err)
+	}
+
+	sessions, err := Sessions()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(sessions) != 2 || sessions[0].Project != "api" || sessions[1].Project != "web" {
+		t.Errorf("Sessions() = %+v", sessions)
+	}
+}
+
+func TestStopIdle(t *testing.T) {
+	setup(t)
+
+	if _, err := Start("api", "/ws/api", true); err != nil {
+		t.Fatal(err)
+	}
+	running, _ := Current()
+
+	if stopped, err := StopIdle(running.Last.Add(IdleTimeout)); err != nil || stopped != nil {
+		t.Fatalf("StopIdle before the timeout stopped %+v, %v", stopped, err)
+	}
+
+	stopped, err := StopIdle(running.Last.Add(IdleTimeout + time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stopped == nil || !stopped.End.Equal(running.Last) {
+		t.Fatalf("an idle session was stopped as %+v, want it to end at its last activity", stopped)
+	}
+	if s, _ := Current(); s != nil {
+		t.Error("the idle session is still running")
+	}
+}
+
+func TestStopIdleLeavesManualSessions(t *testing.T) {
+	setup(t)
+
+	if _, err := Start("api", "/ws/api", false); err != nil {
+		t.Fatal(err)
+	}
+	if stopped, err := StopIdle(time.Now().Add(24 * time.Hour)); err != nil || stopped != nil {
+		t.Fatalf("StopIdle stopped a manual session: %+v, %v", stopped, err)
+	}
+}
+
+func TestTouchKeepsSessionAlive(t *testing.T) {
+	setup(t)
+
+	if _, err := Start("api", "/ws/api", true); err != nil {
+		t.Fatal(err)
+	}
+	running, _ := Current()
+	running.Last = running.Last.Add(-time.Hour)
+	if err := writeCurrent(*running); err != nil {
+		t.Fatal(err)
+	}
+	if err := Touch(*running); err != nil {
+		t.Fatal(err)
+	}
+
+	if stopped, _ := StopIdle(time.Now()); stopped != nil {
+		t.Error("a touched session was stopped as idle")
+	}
+}
+
+func TestTotals(t *testing.T) {
+	monday := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	at := func(day, hour int) time.Time { return monday.AddDate(0, 0, day).Add(time.Duration(hour) * time.Hour) }
+	sessions := []Session{
+		{Project: "api", Path: "/ws/api", Start: at(-1, 22), End: at(0, 2)},
+		{Project: "web", Path: "/ws/web", Start: at(1, 9), End: at(1, 10)},
+		{Project: "api", Path: "/ws/api", Start: at(2, 9), End: at(2, 12)},
+		{Project: "web", Path: "/ws/web", Start: at(3, 9)},
+	}
+	now := at(3, 12)
+
+	week := Totals(sessions, monday, now)
+	want := []Total{
+		{Project: "api", Path: "/ws/api", Duration: 5 * time.Hour},
+		{Project: "web", Path: "/ws/web", Duration: 4 * time.Hour},
+	}
+	if len(week) != len(want) {
+		t.Fatalf("Totals = %+v, want %+v", week, want)
+	}
+	for i := range want {
+		if week[i] != want[i] {
+			t.Errorf("Totals[%d] = %+v, want %+v", i, week[i], want[i])
+		}
+	}
+
+	if all := Totals(sessions, time.Time{}, now); all[0].Duration != 7*time.Hour {
+		t.Errorf("total for api = %v, want 7h", all[0].Duration)
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	want := time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)
+	for day := range 7 {
+		at := want.AddDate(0, 0, day).Add(15 * time.Hour)
+		if got := WeekStart(at); !got.Equal(want) {
+			t.Errorf("WeekStart(%s) = %s, want %s", at.Weekday(), got, want)
+		}
+	}
+}
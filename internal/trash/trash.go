@@ -0,0 +1,313 @@
+package trash
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+const (
+	KindProject   = "project"
+	KindWorkspace = "workspace"
+)
+
+type Entry struct {
+	ID           string                    `json:"id"`
+	Kind         string                    `json:"kind"`
+	Name         string                    `json:"name"`
+	OriginalPath string                    `json:"original_path"`
+	DeletedAt    time.Time                 `json:"deleted_at"`
+	HasFiles     bool                      `json:"has_files"`
+	Workspace    string                    `json:"workspace,omitempty"`
+	Projects     map[string]config.Project `json:"projects,omitempty"`
+}
+
+func getTrashDir() (string, error) {
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		return "", err
+	}
+	trashDir := filepath.Join(configDir, "trash")
+	if err := os.MkdirAll(trashDir, 0755); err != nil {
+		return "", err
+	}
+	return trashDir, nil
+}
+
+func (e Entry) dir(trashDir string) string {
+	return filepath.Join(trashDir, e.ID)
+}
+
+func (e Entry) itemPath(trashDir string) string {
+	return filepath.Join(trashDir, e.ID, "item")
+}
+
+func Put(entry Entry) (*Entry, error) {
+	trashDir, err := getTrashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	entry.DeletedAt = time.Now()
+	entry.ID = fmt.Sprintf("%s-%s", entry.DeletedAt.Format("20060102-150405"), entry.Name)
+	if _, err := os.Stat(entry.dir(trashDir)); err == nil {
+		entry.ID = fmt.Sprintf("%s-%d", entry.ID, entry.DeletedAt.Nanosecond())
+	}
+
+	if err := os.MkdirAll(entry.dir(trashDir), 0755); err != nil {
+		return nil, err
+	}
+
+	// The entry is written before the files move, so files in the trash can
+	// always be listed and restored.
+	if err := writeEntry(trashDir, entry); err != nil {
+		os.RemoveAll(entry.dir(trashDir))
+		return nil, err
+	}
+
+	if entry.HasFiles {
+		if err := moveDir(entry.OriginalPath, entry.itemPath(trashDir)); err != nil {
+			os.RemoveAll(entry.dir(trashDir))
+			return nil, err
+		}
+	}
+
+	return &entry, nil
+}
+
+func writeEntry(trashDir string, entry Entry) error {
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(entry.dir(trashDir), "entry.json"), data, 0644)
+}
+
+func List() ([]Entry, error) {
+	trashDir, err := getTrashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	dirs, err := os.ReadDir(trashDir)
+	if err != nil {
+		return nil, err
+	}
+
+	entries := []Entry{}
+	for _, d := range dirs {
+		if !d.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(trashDir, d.Name(), "entry.json"))
+		if err != nil {
+			continue
+		}
+		var entry Entry
+		if err := json.Unmarshal(data, &entry); err != nil {
+			continue
+		}
+		entries = append(entries, entry)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].DeletedAt.After(entries[j].DeletedAt)
+	})
+
+	return entries, nil
+}
+
+func Last() (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 {
+		return nil, fmt.Errorf("trash is empty")
+	}
+	return &entries[0], nil
+}
+
+func find(id string) (*Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range entries {
+		if e.ID == id {
+			return &e, nil
+		}
+	}
+	return nil, fmt.Errorf("trash entry '%s' not found", id)
+}
+
+// Restore loads the config before moving any files back, and returns them to
+// the trash if the config cannot be saved, so a failed restore can be retried.
+func Restore(id string) (*Entry, error) {
+	entry, err := find(id)
+	if err != nil {
+		return nil, err
+	}
+
+	trashDir, err := getTrashDir()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+
+	if entry.Workspace != "" {
+		exists := false
+		for _, w := range cfg.Workspaces {
+			if w == entry.Workspace {
+				exists = true
+				break
+			}
+		}
+		if !exists {
+			cfg.Workspaces = append(cfg.Workspaces, entry.Workspace)
+		}
+	}
+
+	for name, p := range entry.Projects {
+		if _, exists := cfg.Projects[name]; !exists {
+			cfg.Projects[name] = p
+		}
+	}
+
+	if entry.HasFiles {
+		if _, err := os.Stat(entry.OriginalPath); err == nil {
+			return nil, fmt.Errorf("'%s' already exists", entry.OriginalPath)
+		}
+		if err := os.MkdirAll(filepath.Dir(entry.OriginalPath), 0755); err != nil {
+			return nil, err
+		}
+		if err := moveDir(entry.itemPath(trashDir), entry.OriginalPath); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := config.Save(cfg); err != nil {
+		if entry.HasFiles {
+			if moveErr := moveDir(entry.OriginalPath, entry.itemPath(trashDir)); moveErr != nil {
+				return nil, fmt.Errorf("%w; the files stay at '%s': %v", err, entry.OriginalPath, moveErr)
+			}
+		}
+		return nil, err
+	}
+
+	if err := os.RemoveAll(entry.dir(trashDir)); err != nil {
+		return nil, err
+	}
+
+	return entry, nil
+}
+
+// Expired returns the entries deleted longer than olderThan ago, or every
+// entry when olderThan is zero.
+func Expired(olderThan time.Duration) ([]Entry, error) {
+	entries, err := List()
+	if err != nil {
+		return nil, err
+	}
+
+	var expired []Entry
+	cutoff := time.Now().Add(-olderThan)
+	for _, e := range entries {
+		if olderThan > 0 && e.DeletedAt.After(cutoff) {
+			continue
+		}
+		expired = append(expired, e)
+	}
+	return expired, nil
+}
+
+func Empty(olderThan time.Duration) (int, error) {
+	entries, err := Expired(olderThan)
+	if err != nil {
+		return 0, err
+	}
+
+	trashDir, err := getTrashDir()
+	if err != nil {
+		return 0, err
+	}
+
+	removed := 0
+	for _, e := range entries {
+		if err := os.RemoveAll(e.dir(trashDir)); err != nil {
+			return removed, err
+		}
+		removed++
+	}
+
+	return removed, nil
+}
+
+func moveDir(src, dst string) error {
+	if err := os.Rename(src, dst); err == nil {
+		return nil
+	}
+
+	if err := copyDir(src, dst); err != nil {
+		os.RemoveAll(dst)
+		return err
+	}
+	return os.RemoveAll(src)
+}
+
+func copyDir(src, dst string) error {
+	return filepath.Walk(src, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+
+		rel, err := filepath.Rel(src, path)
+		if err != nil {
+			return err
+		}
+		target := filepath.Join(dst, rel)
+
+		switch {
+		case info.IsDir():
+			return os.MkdirAll(target, info.Mode().Perm()|0700)
+		case info.Mode()&os.ModeSymlink != 0:
+			link, err := os.Readlink(path)
+			if err != nil {
+				return err
+			}
+			return os.Symlink(link, target)
+		case info.Mode().IsRegular():
+			return copyFile(path, target, info.Mode().Perm())
+		}
+		return nil
+	})
+}
+
+func copyFile(src, dst string, mode os.FileMode) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.OpenFile(dst, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, mode)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
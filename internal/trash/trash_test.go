@@ -0,0 +1,186 @@
+package trash
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// setup points the config dir at a temporary home and returns a project
+// directory with one file in it.
+func setup(t *testing.T) string {
+	t.Helper()
+	home := t.TempDir()
+	t.Setenv("HOME", home)
+
+	projectPath := filepath.Join(home, "ws", "demo")
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(projectPath, "README.md"), []byte("# demo\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return projectPath
+}
+
+func TestPutAndRestore(t *testing.T) {
+	projectPath := setup(t)
+	project := config.Project{Name: "demo", Path: projectPath, Status: "active"}
+
+	entry, err := Put(Entry{
+		Kind:         KindProject,
+		Name:         "demo",
+		OriginalPath: projectPath,
+		HasFiles:     true,
+		Projects:     map[string]config.Project{"demo": project},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+		t.Fatal("Put left the project directory in place")
+	}
+
+	last, err := Last()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.ID != entry.ID {
+		t.Errorf("Last() = %s, want %s", last.ID, entry.ID)
+	}
+
+	if _, err := Restore(entry.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(filepath.Join(projectPath, "README.md")); err != nil {
+		t.Errorf("README not restored: %v", err)
+	}
+	cfg, err := config.Load()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := cfg.Projects["demo"]; got.Path != projectPath || got.Status != "active" {
+		t.Errorf("restored config entry = %+v", got)
+	}
+	if entries, _ := List(); len(entries) != 0 {
+		t.Errorf("trash still holds %d entries after restore", len(entries))
+	}
+}
+
+func TestPutFailureLeavesNothing(t *testing.T) {
+	projectPath := setup(t)
+
+	_, err := Put(Entry{
+		Kind:         KindProject,
+		Name:         "missing",
+		OriginalPath: filepath.Join(filepath.Dir(projectPath), "missing"),
+		HasFiles:     true,
+	})
+	if err == nil {
+		t.Fatal("Put of a missing directory succeeded")
+	}
+
+	trashDir, err := getTrashDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if dirs, _ := os.ReadDir(trashDir); len(dirs) != 0 {
+		t.Errorf("a failed Put left %d item(s) in the trash", len(dirs))
+	}
+}
+
+func TestRestoreRefusesExistingPath(t *testing.T) {
+	projectPath := setup(t)
+
+	entry, err := Put(Entry{Kind: KindProject, Name: "demo", OriginalPath: projectPath, HasFiles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.MkdirAll(projectPath, 0755); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Restore(entry.ID); err == nil {
+		t.Fatal("Restore overwrote an existing directory")
+	}
+	if entries, _ := List(); len(entries) != 1 {
+		t.Error("the entry was lost after a refused restore")
+	}
+}
+
+func TestRestoreKeepsEntryWhenConfigIsBroken(t *testing.T) {
+	projectPath := setup(t)
+
+	entry, err := Put(Entry{Kind: KindProject, Name: "demo", OriginalPath: projectPath, HasFiles: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	configDir, err := config.GetConfigDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+	configPath := filepath.Join(configDir, "orbit.json")
+	if err := os.WriteFile(configPath, []byte("{"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := Restore(entry.ID); err == nil {
+		t.Fatal("Restore succeeded with a broken config")
+	}
+	if _, err := os.Stat(projectPath); !os.IsNotExist(err) {
+		t.Fatal("the files were moved back although the config could not be loaded")
+	}
+
+	if err := os.Remove(configPath); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Restore(entry.ID); err != nil {
+		t.Fatalf("retrying the restore failed: %v", err)
+	}
+}
+
+func TestEmptyOlderThan(t *testing.T) {
+	setup(t)
+	trashDir, err := getTrashDir()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	old := Entry{ID: "old", Kind: KindProject, Name: "old", DeletedAt: time.Now().Add(-48 * time.Hour)}
+	recent := Entry{ID: "recent", Kind: KindProject, Name: "recent", DeletedAt: time.Now()}
+	for _, e := range []Entry{old, recent} {
+		if err := os.MkdirAll(e.dir(trashDir), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := writeEntry(trashDir, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expired, err := Expired(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(expired) != 1 || expired[0].ID != "old" {
+		t.Fatalf("Expired(24h) = %v, want only the old entry", expired)
+	}
+
+	removed, err := Empty(24 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if removed != 1 {
+		t.Errorf("Empty(24h) removed %d, want 1", removed)
+	}
+	entries, _ := List()
+	if len(entries) != 1 || entries[0].ID != "recent" {
+		t.Errorf("after Empty(24h) the trash holds %v", entries)
+	}
+
+	if removed, _ := Empty(0); removed != 1 {
+		t.Errorf("Empty(0) removed %d, want the remaining 1", removed)
+	}
+}
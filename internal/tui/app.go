@@ -0,0 +1,357 @@
+package tui
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/frecency"
+	"github.com/henrynguci/orbit/internal/hooks"
+	"github.com/henrynguci/orbit/internal/timelog"
+	"github.com/henrynguci/orbit/internal/utils"
+)
+
+// appState is shared by every screen on the stack.
+type appState struct {
+	cfg        *config.Config
+	width      int
+	height     int
+	toast      string
+	toastError bool
+	gotoPath   string
+	gotoEvent  hooks.Event
+	hookOutput bytes.Buffer
+	keys       keyMap
+	// visits are the frecency records of project directories.
+	visits map[string]frecency.Entry
+	// session is the running time tracking session, if any, and week the
+	// time of the finished sessions this week by project path.
+	session *timelog.Session
+	week    map[string]time.Duration
+}
+
+// errConfigLoad marks a reload that could not read the config.
+var errConfigLoad = errors.New("failed to load config")
+
+// newAppState loads the config for a TUI session. Workspaces that no longer
+// exist are dropped from the config file; a config that does not load is an
+// error rather than a reason to start from an empty one.
+func newAppState() (*appState, error) {
+	cfg, err := config.Load()
+	if err != nil {
+		return nil, err
+	}
+	if workspaces := filterExistingWorkspaces(cfg.Workspaces); len(workspaces) != len(cfg.Workspaces) {
+		cfg.Workspaces = workspaces
+		if err := config.Save(cfg); err != nil {
+			return nil, err
+		}
+	}
+
+	s := &appState{}
+	if err := s.reload(); err != nil {
+		if errors.Is(err, errConfigLoad) {
+			return nil, err
+		}
+		s.toast = err.Error()
+		s.toastError = true
+	}
+	return s, nil
+}
+
+// reload reads the config again. When it cannot be read the config in memory
+// is kept, so nothing is saved over the file, and errConfigLoad is returned.
+// Otherwise the error reports problems with the key bindings, which fall back
+// to their defaults, and with the statuses.
+func (s *appState) reload() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return fmt.Errorf("%w: %v", errConfigLoad, err)
+	}
+	cfg.Workspaces = filterExistingWorkspaces(cfg.Workspaces)
+	s.cfg = cfg
+
+	s.visits, _ = frecency.Load()
+	s.loadTime()
+
+	keys, err := loadKeyMap(cfg.Keys)
+	s.keys = keys
+	return errors.Join(err, config.ValidateStatuses(cfg))
+}
+
+// loadTime reads the running session and this week's finished ones.
+func (s *appState) loadTime() {
+	s.session, _ = timelog.Current()
+
+	s.week = make(map[string]time.Duration)
+	sessions, _ := timelog.Sessions()
+	for _, t := range timelog.Totals(sessions, timelog.WeekStart(time.Now()), time.Now()) {
+		s.week[t.Path] = t.Duration
+	}
+}
+
+// weekTime is the time tracked on the project at path this week, including
+// the running session.
+func (s *appState) weekTime(path string) time.Duration {
+	total := s.week[path]
+	if s.session != nil && s.session.Path == path {
+		now := time.Now()
+		for _, t := range timelog.Totals([]timelog.Session{*s.session}, timelog.WeekStart(now), now) {
+			total += t.Duration
+		}
+	}
+	return total
+}
+
+type pushScreenMsg struct {
+	screen tea.Model
+}
+
+type replaceScreenMsg struct {
+	screen tea.Model
+}
+
+type popScreenMsg struct{}
+
+type configChangedMsg struct{}
+
+// timerTickMsg keeps the session timer in the status bar running.
+type timerTickMsg struct{}
+
+func tickTimer() tea.Cmd {
+	return tea.Tick(time.Second, func(time.Time) tea.Msg { return timerTickMsg{} })
+}
+
+type toastMsg struct {
+	text    string
+	isError bool
+}
+
+type gotoMsg struct {
+	path  string
+	event hooks.Event
+}
+
+func pushScreen(screen tea.Model) tea.Cmd {
+	return func() tea.Msg { return pushScreenMsg{screen: screen} }
+}
+
+func replaceScreen(screen tea.Model) tea.Cmd {
+	return func() tea.Msg { return replaceScreenMsg{screen: screen} }
+}
+
+func popScreen() tea.Msg {
+	return popScreenMsg{}
+}
+
+func reloadConfig() tea.Msg {
+	return configChangedMsg{}
+}
+
+func showToast(text string) tea.Cmd {
+	return func() tea.Msg { return toastMsg{text: text} }
+}
+
+func showErrorToast(text string) tea.Cmd {
+	return func() tea.Msg { return toastMsg{text: text, isError: true} }
+}
+
+// closeDialog pops the dialog on top, reloads the config into every screen and
+// reports the outcome on the screen underneath.
+func closeDialog(toast tea.Cmd) tea.Cmd {
+	return tea.Sequence(popScreen, reloadConfig, toast)
+}
+
+type appModel struct {
+	state *appState
+	stack []tea.Model
+}
+
+func (m appModel) Init() tea.Cmd {
+	return tea.Batch(m.top().Init(), tickTimer())
+}
+
+func (m appModel) top() tea.Model {
+	return m.stack[len(m.stack)-1]
+}
+
+func (m appModel) sized(screen tea.Model) tea.Model {
+	if m.state.width == 0 {
+		return screen
+	}
+	screen, _ = screen.Update(tea.WindowSizeMsg{Width: m.state.width, Height: m.state.height})
+	return screen
+}
+
+func (m appModel) broadcast(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmds []tea.Cmd
+	for i, screen := range m.stack {
+		var cmd tea.Cmd
+		m.stack[i], cmd = screen.Update(msg)
+		cmds = append(cmds, cmd)
+	}
+	return m, tea.Batch(cmds...)
+}
+
+func (m appModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.state.width = msg.Width
+		m.state.height = msg.Height
+		return m.broadcast(msg)
+	case configChangedMsg:
+		if err := m.state.reload(); errors.Is(err, errConfigLoad) {
+			m.state.toast = err.Error()
+			m.state.toastError = true
+		}
+		return m.broadcast(msg)
+	case pushScreenMsg:
+		m.stack = append(m.stack, m.sized(msg.screen))
+		return m, msg.screen.Init()
+	case replaceScreenMsg:
+		m.stack[len(m.stack)-1] = m.sized(msg.screen)
+		return m, msg.screen.Init()
+	case popScreenMsg:
+		m.stack = m.stack[:len(m.stack)-1]
+		if len(m.stack) == 0 {
+			return m, tea.Quit
+		}
+		return m, nil
+	case timerTickMsg:
+		// Sessions can be started and stopped from another terminal.
+		prev := m.state.session
+		m.state.session, _ = timelog.Current()
+		if cur := m.state.session; (prev == nil) != (cur == nil) || (prev != nil && !prev.Start.Equal(cur.Start)) {
+			m.state.loadTime()
+		}
+		return m, tickTimer()
+	case toastMsg:
+		m.state.toast = msg.text
+		m.state.toastError = msg.isError
+		return m, nil
+	case gotoMsg:
+		m.state.gotoPath = msg.path
+		m.state.gotoEvent = msg.event
+		return m, tea.Quit
+	case tea.KeyMsg:
+		m.state.toast = ""
+	}
+
+	var cmd tea.Cmd
+	m.stack[len(m.stack)-1], cmd = m.top().Update(msg)
+	return m, cmd
+}
+
+// View puts the status bar under the screen: the running session timer,
+// followed by the toast.
+func (m appModel) View() string {
+	view := m.top().View()
+
+	var bar []string
+	if s := m.state.session; s != nil {
+		bar = append(bar, sessionStyle.Render("⏱ "+s.Project+" "+formatTimer(s.Duration(time.Now()))))
+	}
+	if m.state.toast != "" {
+		style := lipgloss.NewStyle().Foreground(successColor).Bold(true)
+		prefix := "✓ "
+		if m.state.toastError {
+			style = lipgloss.NewStyle().Foreground(errorColor).Bold(true)
+			prefix = "✗ "
+		}
+		bar = append(bar, style.Render(prefix+m.state.toast))
+	}
+
+	if len(bar) == 0 {
+		return view
+	}
+	return view + "\n" + strings.Join(bar, "  ")
+}
+
+// formatTimer writes a running session as h:mm:ss.
+func formatTimer(d time.Duration) string {
+	d = d.Round(time.Second)
+	return fmt.Sprintf("%d:%02d:%02d", int(d.Hours()), int(d.Minutes())%60, int(d.Seconds())%60)
+}
+
+// RunMainTUI starts on the workspaces. Inside a project directory it opens
+// that project's workspace with the project selected.
+func RunMainTUI() error {
+	return run(func(state *appState) []tea.Model {
+		workspaces := newWorkspaceScreen(state)
+
+		project, ok := config.CurrentProject(state.cfg)
+		workspace := config.WorkspaceOf(state.cfg, project)
+		if !ok || !workspaces.selectWorkspace(workspace) {
+			return []tea.Model{workspaces}
+		}
+
+		workspaces.showBanner = false
+		projects := newProjectScreen(state, workspace)
+		projects.selectProject(project.Path)
+		return []tea.Model{workspaces, projects}
+	})
+}
+
+// RunDashboardTUI starts on the dashboard, showing only the projects sel
+// matches.
+func RunDashboardTUI(sel config.Selector) error {
+	return run(func(state *appState) []tea.Model {
+		return []tea.Model{newFilteredDashboardScreen(state, sel)}
+	})
+}
+
+// run starts the TUI on the given screens. It fails only when the config
+// cannot be loaded.
+func run(screens func(*appState) []tea.Model) error {
+	state, err := newAppState()
+	if err != nil {
+		return err
+	}
+
+	hooks.SetOutput(&state.hookOutput)
+
+	m := appModel{
+		state: state,
+		stack: screens(state),
+	}
+
+	p := tea.NewProgram(m, tea.WithAltScreen())
+	final, err := p.Run()
+	hooks.SetOutput(nil)
+	if final, ok := final.(appModel); ok {
+		for _, screen := range final.stack {
+			if task, ok := screen.(taskOutputModel); ok {
+				task.stop()
+			}
+		}
+	}
+	if err != nil {
+		return nil
+	}
+
+	if state.gotoPath != "" {
+		execShell(state.cfg, state.gotoPath, state.gotoEvent)
+	}
+	return nil
+}
+
+func execShell(cfg *config.Config, path string, ev hooks.Event) {
+	if err := os.Chdir(path); err != nil {
+		printError(fmt.Sprintf("Failed to access directory: %v", err))
+		return
+	}
+
+	if err := hooks.Post(cfg, ev); err != nil {
+		printError(err.Error())
+	}
+
+	clearScreen()
+	utils.ExecShell()
+	os.Exit(0)
+}
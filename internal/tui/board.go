@@ -0,0 +1,398 @@
+package tui
+
+import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/theme"
+)
+
+// boardCardHeight is the number of lines a card takes, borders included.
+const boardCardHeight = 4
+
+// boardColumn holds the projects that share a status.
+type boardColumn struct {
+	status string
+	cards  []config.Project
+}
+
+// boardFilterMsg is sent by the filter dialogs once they have closed.
+type boardFilterMsg struct {
+	workspace string
+	tag       string
+}
+
+// boardModel shows projects as cards in one column per status. Moving a card
+// to the next column changes the project's status.
+type boardModel struct {
+	state   *appState
+	columns []boardColumn
+	// workspace and tag narrow the board down; empty means all.
+	workspace string
+	tag       string
+	col       int
+	rows      []int
+	offsets   []int
+	// follow is the path of a card that was just moved, so the cursor can
+	// find it again once the config has been reloaded.
+	follow string
+}
+
+func newBoardScreen(state *appState) boardModel {
+	m := boardModel{state: state}
+	m.setCards()
+	return m
+}
+
+// setCards groups the projects into columns, keeping the cursor on the same
+// card if it is still shown.
+func (m *boardModel) setCards() {
+	current, hadCurrent := m.current()
+	if m.follow != "" {
+		current, hadCurrent = config.Project{Path: m.follow}, true
+		m.follow = ""
+	}
+
+	cfg := m.state.cfg
+	statuses := config.StatusNames(cfg)
+	columns := make([]boardColumn, len(statuses))
+	index := make(map[string]int)
+	for i, s := range statuses {
+		columns[i].status = s
+		index[s] = i
+	}
+
+	for _, p := range config.GetAllProjects(cfg) {
+		if m.workspace != "" && config.WorkspaceOf(cfg, p) != m.workspace {
+			continue
+		}
+		if m.tag != "" && !config.HasTag(p, m.tag) {
+			continue
+		}
+
+		status := config.StatusOf(p)
+		i, ok := index[status]
+		if !ok {
+			// Statuses set by hand in the config get a column of their own
+			// after the usual ones.
+			i = len(columns)
+			index[status] = i
+			columns = append(columns, boardColumn{status: status})
+		}
+		columns[i].cards = append(columns[i].cards, p)
+	}
+
+	extra := columns[len(statuses):]
+	sort.Slice(extra, func(i, j int) bool { return extra[i].status < extra[j].status })
+	for _, c := range columns {
+		sort.Slice(c.cards, func(i, j int) bool {
+			return strings.ToLower(c.cards[i].Name) < strings.ToLower(c.cards[j].Name)
+		})
+	}
+
+	m.columns = columns
+	m.rows = resizeInts(m.rows, len(columns))
+	m.offsets = resizeInts(m.offsets, len(columns))
+	m.col = clampCursor(m.col, len(columns))
+
+	if hadCurrent {
+		for i, c := range columns {
+			for j, p := range c.cards {
+				if p.Path == current.Path {
+					m.col, m.rows[i] = i, j
+				}
+			}
+		}
+	}
+	m.scroll()
+}
+
+func resizeInts(s []int, n int) []int {
+	for len(s) < n {
+		s = append(s, 0)
+	}
+	return s[:n]
+}
+
+// scroll clamps every column's cursor and keeps it on screen.
+func (m *boardModel) scroll() {
+	page := m.pageSize()
+	for i, c := range m.columns {
+		m.rows[i] = clampCursor(m.rows[i], len(c.cards))
+		m.offsets[i] = scrollOffset(m.offsets[i], m.rows[i], len(c.cards), page)
+	}
+}
+
+// current returns the card under the cursor.
+func (m boardModel) current() (config.Project, bool) {
+	if m.col >= len(m.columns) || len(m.columns[m.col].cards) == 0 {
+		return config.Project{}, false
+	}
+	return m.columns[m.col].cards[m.rows[m.col]], true
+}
+
+func (m boardModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m boardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.scroll()
+	case configChangedMsg:
+		m.setCards()
+	case boardFilterMsg:
+		m.workspace = msg.workspace
+		m.tag = msg.tag
+		m.setCards()
+	case tea.KeyMsg:
+		keys := m.state.keys
+
+		// Moving cards comes first: with the vim preset h and l are also
+		// bound to back and select.
+		switch {
+		case keys.matches(msg, keyQuit):
+			return m, tea.Quit
+		case keys.matches(msg, keyHelp):
+			return m, pushScreen(newHelpScreen(m.state, "Board", helpGroup{
+				title:   "Navigation",
+				actions: []string{keyLeft, keyRight, keyUp, keyDown, keyPageUp, keyPageDown, keyTop, keyBottom},
+			}, helpGroup{
+				title:   "Board",
+				actions: []string{keyMoveLeft, keyMoveRight, keySelect, keyStatus, keyGoto, keyWorkspace, keyTag, keyBack, keyHelp, keyQuit},
+			}))
+		case keys.matches(msg, keyMoveLeft):
+			cmd := m.move(-1)
+			return m, cmd
+		case keys.matches(msg, keyMoveRight):
+			cmd := m.move(1)
+			return m, cmd
+		case keys.matches(msg, keyLeft):
+			m.col = clampCursor(m.col-1, len(m.columns))
+			return m, nil
+		case keys.matches(msg, keyRight):
+			m.col = clampCursor(m.col+1, len(m.columns))
+			return m, nil
+		}
+
+		if m.col < len(m.columns) {
+			count := len(m.columns[m.col].cards)
+			if cursor, ok := navigate(keys, msg, m.rows[m.col], count, m.pageSize()); ok {
+				m.rows[m.col] = cursor
+				m.scroll()
+				return m, nil
+			}
+		}
+
+		switch {
+		case keys.matches(msg, keyBack):
+			return m, popScreen
+		case keys.matches(msg, keyWorkspace):
+			return m, pushScreen(m.workspaceDialog())
+		case keys.matches(msg, keyTag):
+			return m, pushScreen(m.tagDialog())
+		}
+
+		p, ok := m.current()
+		if !ok {
+			return m, nil
+		}
+		switch {
+		case keys.matches(msg, keySelect):
+			return m, showProjectView(m.state, p)
+		case keys.matches(msg, keyStatus):
+			return m, pushScreen(handleChangeStatus(m.state, p.Name))
+		case keys.matches(msg, keyGoto):
+			return m, gotoDirectory(m.state, p.Path)
+		}
+	}
+	return m, nil
+}
+
+// move changes the status of the current card to the one of the column next
+// to it, through the same hooks as the status dialog.
+func (m *boardModel) move(delta int) tea.Cmd {
+	p, ok := m.current()
+	target := m.col + delta
+	if !ok || target < 0 || target >= len(m.columns) {
+		return nil
+	}
+
+	veto, toast := setProjectStatus(m.state, p.Name, p, m.columns[target].status, "")
+	if veto != nil {
+		return pushScreen(veto)
+	}
+	m.follow = p.Path
+	return tea.Sequence(reloadConfig, toast)
+}
+
+func (m boardModel) workspaceDialog() tea.Model {
+	workspaces := m.state.cfg.Workspaces
+	items := []string{"All workspaces"}
+	for _, w := range workspaces {
+		items = append(items, filepath.Base(w))
+	}
+
+	tag := m.tag
+	return newSelectDialog("Board", "", "Show projects from:", items, func(i int) tea.Cmd {
+		workspace := ""
+		if i > 0 {
+			workspace = workspaces[i-1]
+		}
+		return tea.Sequence(popScreen, func() tea.Msg { return boardFilterMsg{workspace: workspace, tag: tag} })
+	})
+}
+
+func (m boardModel) tagDialog() tea.Model {
+	seen := make(map[string]bool)
+	var tags []string
+	for _, p := range config.GetAllProjects(m.state.cfg) {
+		for _, t := range p.Tags {
+			if !seen[strings.ToLower(t)] {
+				seen[strings.ToLower(t)] = true
+				tags = append(tags, t)
+			}
+		}
+	}
+	if len(tags) == 0 {
+		return newMessageDialog("Board", "No project has any tags yet.")
+	}
+	sort.Strings(tags)
+	items := append([]string{"All tags"}, tags...)
+
+	workspace := m.workspace
+	return newSelectDialog("Board", "", "Show projects tagged:", items, func(i int) tea.Cmd {
+		tag := ""
+		if i > 0 {
+			tag = tags[i-1]
+		}
+		return tea.Sequence(popScreen, func() tea.Msg { return boardFilterMsg{workspace: workspace, tag: tag} })
+	})
+}
+
+func (m boardModel) header() string {
+	workspace, tag := "all", "all"
+	if m.workspace != "" {
+		workspace = filepath.Base(m.workspace)
+	}
+	if m.tag != "" {
+		tag = m.tag
+	}
+
+	total := 0
+	for _, c := range m.columns {
+		total += len(c.cards)
+	}
+
+	s := "\n" + renderTitle("Board", m.state.width) + "\n\n"
+	s += hintStyle.Render(fmt.Sprintf("  Workspace: %s  ·  Tag: %s  ·  %d projects", workspace, tag, total)) + "\n\n"
+	return s
+}
+
+func (m boardModel) footer() string {
+	keys := m.state.keys
+	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
+		blueBtn.Render(keys.label(keyMoveLeft)+"/"+keys.label(keyMoveRight)+" Move"),
+		blueBtn.Render(keys.label(keyStatus)+" Status"),
+		greenBtn.Render(keys.label(keyWorkspace)+" Workspace"),
+		greenBtn.Render(keys.label(keyTag)+" Tag"),
+		yellowBtn.Render(keys.label(keyBack)+" Return"),
+		purpleBtn.Render(keys.label(keyQuit)+" Quit"),
+	)
+	width := screenWidth(m.state.width)
+	hints := hintStyle.MaxWidth(width).Render("  " + strings.Join([]string{
+		keys.hint("Column", keyLeft, keyRight),
+		keys.hint("Card", keyUp, keyDown),
+		keys.hint("Details", keySelect),
+		keys.hint("Help", keyHelp),
+	}, "  "))
+	return lipgloss.NewStyle().MaxWidth(width).Render(helpBar) + "\n\n" + hints
+}
+
+// pageSize returns how many cards fit in a column, leaving room for the
+// column titles, the blank line above the footer and toasts.
+func (m boardModel) pageSize() int {
+	if m.state.height <= 0 {
+		return 1 << 20
+	}
+	height := m.state.height - lipgloss.Height(m.header()) - lipgloss.Height(m.footer()) - 3
+	return max(height/boardCardHeight, 1)
+}
+
+func (m boardModel) View() string {
+	if len(m.columns) == 0 {
+		return m.header() + m.footer()
+	}
+
+	width := screenWidth(m.state.width) / len(m.columns)
+	page := m.pageSize()
+
+	var columns []string
+	for i, c := range m.columns {
+		columns = append(columns, m.renderColumn(i, c, width, page))
+	}
+	body := lipgloss.JoinHorizontal(lipgloss.Top, columns...)
+	return m.header() + body + "\n\n" + m.footer()
+}
+
+func (m boardModel) renderColumn(i int, c boardColumn, width, page int) string {
+	title := renderStatus(m.state.cfg, c.status) + subtitleStyle.Render(fmt.Sprintf(" (%d)", len(c.cards)))
+	offset := m.offsets[i]
+	if len(c.cards) > page {
+		title += subtitleStyle.Render(fmt.Sprintf(" %d–%d", offset+1, min(offset+page, len(c.cards))))
+	}
+	if i == m.col {
+		title = lipgloss.NewStyle().Bold(true).Render("▸ ") + title
+	} else {
+		title = "  " + title
+	}
+	lines := []string{lipgloss.NewStyle().MaxWidth(width).Render(title)}
+
+	if len(c.cards) == 0 {
+		lines = append(lines, hintStyle.Render("  empty"))
+	}
+	end := min(offset+page, len(c.cards))
+	for j, p := range c.cards[offset:end] {
+		lines = append(lines, m.renderCard(p, width, i == m.col && offset+j == m.rows[i]))
+	}
+	return lipgloss.NewStyle().Width(width).Render(strings.Join(lines, "\n"))
+}
+
+func (m boardModel) renderCard(p config.Project, width int, selected bool) string {
+	// One column is left between cards.
+	inner := max(width-5, 4)
+
+	name := p.Name
+	if p.Alias != "" && p.Alias != p.Name {
+		name += " (" + p.Alias + ")"
+	}
+
+	var details []string
+	if w := config.WorkspaceOf(m.state.cfg, p); w != "" {
+		details = append(details, filepath.Base(w))
+	}
+	for _, t := range p.Tags {
+		details = append(details, "#"+t)
+	}
+
+	style := lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(mutedColor).
+		Width(inner+2).
+		Padding(0, 1)
+	nameStyle := lipgloss.NewStyle().Foreground(textColor)
+	if selected {
+		style = style.BorderForeground(primaryColor)
+		nameStyle = nameStyle.Foreground(primaryColor).Bold(true)
+	}
+
+	return style.Render(
+		nameStyle.Render(truncateString(name, inner)) + "\n" +
+			subtitleStyle.Render(truncateString(strings.Join(details, " "), inner)),
+	)
+}
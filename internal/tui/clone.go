@@ -1,7 +1,9 @@
 package tui
 
 import (
+	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/go-git/go-git/v5"
 	"github.com/go-git/go-git/v5/plumbing/transport"
@@ -34,3 +36,32 @@ func cloneRepository(url, path string) error {
 	_, err := git.PlainClone(path, false, opts)
 	return err
 }
+
+// openProjectRepo opens the git repository of a project, trying its repo/
+// directory before the project directory itself.
+func openProjectRepo(projectPath string) (*git.Repository, bool) {
+	for _, dir := range []string{filepath.Join(projectPath, "repo"), projectPath} {
+		if repo, err := git.PlainOpen(dir); err == nil {
+			return repo, true
+		}
+	}
+	return nil, false
+}
+
+// lastCommitTime returns the time of the HEAD commit of a project's repo, or
+// the zero time when there is no repository or no commits.
+func lastCommitTime(projectPath string) time.Time {
+	repo, ok := openProjectRepo(projectPath)
+	if !ok {
+		return time.Time{}
+	}
+	head, err := repo.Head()
+	if err != nil {
+		return time.Time{}
+	}
+	commit, err := repo.CommitObject(head.Hash())
+	if err != nil {
+		return time.Time{}
+	}
+	return commit.Committer.When
+}
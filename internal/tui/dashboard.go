@@ -6,20 +6,113 @@ import (
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
+	"github.com/henrynguci/orbit/internal/archive"
 	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/utils"
 )
 
 type lipglossDashboardModel struct {
-	workspaces []string
-	projects   map[string]config.Project
-	rawData    []dashboardRow
-	cursor     int
-	selected   string
-	action     string
-	quitting   bool
-	menuOpen   bool
-	menuIndex  int
+	state *appState
+	// sel limits the dashboard to matching projects when it is not empty.
+	sel       config.Selector
+	rawData   []dashboardRow
+	visible   []filteredRow
+	filter    tableFilter
+	sort      tableSort
+	cursor    int
+	offset    int
+	selected  string
+	menuOpen  bool
+	menuIndex int
+	menuItems []menuItem
+	marked    map[string]bool
+}
+
+func newDashboardScreen(state *appState) lipglossDashboardModel {
+	m := lipglossDashboardModel{
+		state:     state,
+		filter:    newTableFilter(),
+		sort:      loadTableSort(state, "dashboard", []string{sortFrecency, sortWorkspace, sortName, sortStatus, sortModified, sortActivity}),
+		marked:    make(map[string]bool),
+		menuItems: actionMenuItems(),
+	}
+	m.setRows(m.data())
+	return m
+}
+
+// newFilteredDashboardScreen is the dashboard limited to the projects sel
+// matches.
+func newFilteredDashboardScreen(state *appState, sel config.Selector) lipglossDashboardModel {
+	m := newDashboardScreen(state)
+	m.sel = sel
+	m.setRows(m.data())
+	return m
+}
+
+// setRows sorts and filters the rows, keeping the cursor on the same row if
+// it is still shown.
+func (m *lipglossDashboardModel) setRows(rows []dashboardRow) {
+	current, hadCurrent := m.current()
+
+	m.rawData = sortItems(m.sort, rows, func(row dashboardRow) sortFields {
+		return m.sort.fields(row.Project, row.Workspace, row.Status, row.Path)
+	})
+	m.applyFilter()
+
+	if hadCurrent {
+		if i, ok := findRow(m.visible, func(index int) bool { return m.rawData[index].Path == current.Path }); ok {
+			m.cursor = i
+			m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+		}
+	}
+}
+
+func (m *lipglossDashboardModel) applyFilter() {
+	m.visible = m.filter.apply(len(m.rawData), func(i int) []string {
+		row := m.rawData[i]
+		return []string{
+			fieldName:        row.Project,
+			fieldAlias:       row.Alias,
+			fieldWorkspace:   row.Workspace,
+			fieldStatus:      row.Status,
+			fieldTags:        strings.Join(row.Tags, " "),
+			fieldDescription: row.Description,
+		}
+	})
+	m.cursor = clampCursor(m.cursor, len(m.visible))
+	m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+}
+
+// current returns the row under the cursor, looked up through the filter.
+func (m lipglossDashboardModel) current() (dashboardRow, bool) {
+	if len(m.visible) == 0 {
+		return dashboardRow{}, false
+	}
+	return m.rawData[m.visible[m.cursor].index], true
+}
+
+func (m lipglossDashboardModel) data() []dashboardRow {
+	cfg := m.state.cfg
+	projects := make(map[string]config.Project)
+	for _, p := range config.GetAllProjects(cfg) {
+		if matched, _ := m.sel.Match(cfg, p); matched {
+			projects[p.Name] = p
+		}
+	}
+
+	_, rawData := prepareDashboardData(cfg.Workspaces, projects)
+	if m.sel.IsEmpty() {
+		return rawData
+	}
+
+	// Workspaces without a matching project are left out.
+	var rows []dashboardRow
+	for _, row := range rawData {
+		if row.Status != "none" {
+			rows = append(rows, row)
+		}
+	}
+	return rows
 }
 
 func (m lipglossDashboardModel) Init() tea.Cmd {
@@ -27,257 +120,246 @@ func (m lipglossDashboardModel) Init() tea.Cmd {
 }
 
 func (m lipglossDashboardModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	switch msg.(type) {
+	case tea.WindowSizeMsg:
+		m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+		return m, nil
+	case configChangedMsg:
+		m.setRows(m.data())
+		return m, nil
+	}
 
 	if m.menuOpen {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "up", "k":
-				if m.menuIndex > 0 {
-					m.menuIndex--
-				}
-			case "down", "j":
-				if m.menuIndex < 2 {
-					m.menuIndex++
-				}
-			case "enter":
-				m.menuOpen = false
-				actions := []string{"code", "cursor", "antigravityy"}
-				m.action = "code_" + actions[m.menuIndex]
-				return m, tea.Quit
-			case "esc", "q":
-				m.menuOpen = false
-				return m, nil
-			}
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.menuOpen, m.menuIndex, cmd = updateMenu(m.state, msg, m.menuItems, m.menuIndex, m.selected)
+			return m, cmd
 		}
 		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			m.quitting = true
-			m.action = "quit"
-			return m, tea.Quit
-		case "r", "esc":
-			m.action = "return"
+		keys := m.state.keys
+
+		var handled bool
+		var cmd tea.Cmd
+		if m.filter, handled, cmd = m.filter.update(keys, msg); handled {
+			m.applyFilter()
+			return m, cmd
+		}
+
+		if cursor, ok := navigate(keys, msg, m.cursor, len(m.visible), m.pageSize()); ok {
+			m.cursor = cursor
+			m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+			return m, nil
+		}
+
+		row, ok := m.current()
+		isProject := ok && row.Status != "none"
+		switch {
+		case keys.matches(msg, keyQuit):
 			return m, tea.Quit
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
+		case keys.matches(msg, keyHelp):
+			return m, pushScreen(newHelpScreen(m.state, "Dashboard", navigationHelp, projectHelp()))
+		case keys.matches(msg, keyBack):
+			return m, popScreen
+		case keys.matches(msg, keySort):
+			m.sort = m.sort.next()
+			m.sort.save()
+			m.setRows(m.rawData)
+		case keys.matches(msg, keyReverse):
+			m.sort = m.sort.reversed()
+			m.sort.save()
+			m.setRows(m.rawData)
+		case keys.matches(msg, keyGoto):
+			if ok {
+				return m, gotoDirectory(m.state, row.Path)
 			}
-		case "down", "j":
-			if m.cursor < len(m.rawData)-1 {
-				m.cursor++
+		case keys.matches(msg, keyDelete):
+			if isProject {
+				return m, pushScreen(handleDeleteProject(m.state, row.Project))
 			}
-		case "g":
-			if len(m.rawData) > 0 {
-				row := m.rawData[m.cursor]
-				m.selected = row.Path
-				m.action = "goto"
-				return m, tea.Quit
+		case keys.matches(msg, keyUndo):
+			return m, handleUndoDelete()
+		case keys.matches(msg, keyStatus):
+			if len(m.marked) > 0 {
+				names := markedNames(m.marked)
+				m.marked = make(map[string]bool)
+				return m, pushScreen(handleBulkChangeStatus(m.state, names))
 			}
-		case "s":
-			if len(m.rawData) > 0 {
-				row := m.rawData[m.cursor]
-				if row.Status != "none" {
-					m.selected = row.Project
-					m.action = "status"
-					return m, tea.Quit
-				}
+			if isProject {
+				return m, pushScreen(handleChangeStatus(m.state, row.Project))
 			}
-		case "m":
-			if len(m.rawData) > 0 {
-				row := m.rawData[m.cursor]
-				if row.Status != "none" {
-					m.selected = row.Path
-					m.menuOpen = true
-					m.menuIndex = 0
-				}
+		case keys.matches(msg, keyEdit):
+			if isProject {
+				return m, pushScreen(handleEditProject(m.state, row.Project))
 			}
-		case "enter":
-			if len(m.rawData) > 0 {
-				row := m.rawData[m.cursor]
-				if row.Status != "none" {
-					m.selected = row.Project
-					m.action = "view"
-					return m, tea.Quit
-				}
+		case keys.matches(msg, keyLink):
+			if isProject {
+				return m, openProjectLink(m.state, row.Project)
+			}
+		case keys.matches(msg, keyNote):
+			if isProject {
+				return m, pushScreen(handleAddNote(m.state, row.Project))
+			}
+		case keys.matches(msg, keyTrack):
+			if isProject {
+				return m, toggleTracking(m.state, row.Project, row.Path)
+			}
+		case keys.matches(msg, keyTasks):
+			if isProject {
+				return m, pushScreen(handleRunTask(row.Path))
+			}
+		case keys.matches(msg, keyMark):
+			if isProject {
+				toggleMarked(m.marked, row.Project)
+			}
+		case keys.matches(msg, keyMenu):
+			if isProject {
+				m.selected = row.Path
+				m.menuOpen = true
+				m.menuIndex = 0
+			}
+		case keys.matches(msg, keySelect):
+			if p, exists := m.state.cfg.Projects[row.Project]; exists && isProject {
+				return m, showProjectView(m.state, p)
 			}
 		}
 	}
-	return m, cmd
+	return m, nil
 }
 
-func (m lipglossDashboardModel) View() string {
-	if m.quitting {
-		return ""
+func (m lipglossDashboardModel) columns() []column {
+	return []column{
+		{title: "Workspace", width: 12, min: 6, drop: 3},
+		{title: "Project", width: 15, min: 8},
+		{title: "Status", width: 8, min: 8},
+		{title: "Tags", width: 12, min: 6, drop: 7},
+		{title: "Description", width: 24, min: 10, drop: 8, flex: true},
+		{title: "Week", width: 9, min: 9, drop: 5},
+		{title: "Last Modified", width: 16, min: 16, drop: 2},
+		{title: "Archive", width: 9, min: 7, drop: 4},
+		{title: "Path", width: 30, min: 12, drop: 6, flex: true},
 	}
+}
 
+// header renders everything above the table. info is the scroll position,
+// which does not change how many lines the header takes.
+func (m lipglossDashboardModel) header(info string) string {
 	var s string
 	s += "\n"
-	s += titleStyle.Render("Dashboard - All Projects") + "\n\n"
+	if m.sel.IsEmpty() {
+		s += renderTitle("Dashboard - All Projects", m.state.width) + "\n\n"
+	} else {
+		s += renderTitle("Dashboard - Matching Projects", m.state.width) + "\n"
+		s += subtitleStyle.Render("  "+selectorSummary(m.sel)) + "\n\n"
+	}
 
+	s += m.filter.view(len(m.visible), len(m.rawData), "rows")
 	if len(m.rawData) > 0 {
-		s += m.renderTable() + "\n\n"
-	} else {
-		s += lipgloss.NewStyle().Foreground(mutedColor).Render("  No projects or workspaces found.") + "\n\n"
+		s += m.sort.view(info)
 	}
+	return s
+}
 
+func (m lipglossDashboardModel) footer() string {
+	keys := m.state.keys
 	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
-		blueBtn.Render("s Status"),
-		blueBtn.Render("g Goto"),
-		greenBtn.Render("m Code"),
-		yellowBtn.Render("r Return"),
-		purpleBtn.Render("q Quit"),
+		blueBtn.Render(keys.label(keyStatus)+" Status"),
+		blueBtn.Render(keys.label(keyEdit)+" Edit"),
+		blueBtn.Render(keys.label(keyNote)+" Note"),
+		blueBtn.Render(keys.label(keyGoto)+" Goto"),
+		redBtn.Render(keys.label(keyDelete)+" Delete"),
+		yellowBtn.Render(keys.label(keyUndo)+" Undo"),
+		greenBtn.Render(keys.label(keyMenu)+" Code"),
+		greenBtn.Render(keys.label(keyTasks)+" Tasks"),
+		yellowBtn.Render(keys.label(keyBack)+" Return"),
+		purpleBtn.Render(keys.label(keyQuit)+" Quit"),
 	)
-	s += helpBar + "\n"
+	s := lipgloss.NewStyle().MaxWidth(screenWidth(m.state.width)).Render(helpBar) + "\n"
 
 	if m.menuOpen {
-		menuItems := []string{"open with code", "open with cursor", "open with antigravityy"}
-		var menuLines []string
-		for i, item := range menuItems {
-			line := "  " + item
-			if i == m.menuIndex {
-				line = " > " + lipgloss.NewStyle().Foreground(primaryColor).Bold(true).Render(item)
-			}
-			menuLines = append(menuLines, line)
+		s += "\n" + renderMenu(m.menuItems, m.menuIndex) + "\n"
+	}
+
+	if len(m.rawData) > 0 && !m.menuOpen {
+		s += hintStyle.MaxWidth(screenWidth(m.state.width)).Render("\n  "+tableHints(keys)) + "\n"
+		if len(m.marked) > 0 {
+			s += lipgloss.NewStyle().Foreground(secondaryColor).Render(markedSummary(keys, len(m.marked))) + "\n"
 		}
+	}
+	return s
+}
 
-		menuBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(primaryColor).
-			Padding(1, 2).
-			Render(lipgloss.JoinVertical(lipgloss.Left, menuLines...))
+func (m lipglossDashboardModel) pageSize() int {
+	return pageSize(m.state.height, m.header(""), m.footer())
+}
 
-		s += "\n" + lipgloss.NewStyle().MarginLeft(4).Render(menuBox) + "\n"
-	}
+func (m lipglossDashboardModel) View() string {
+	page := m.pageSize()
+	s := m.header(scrollInfo(scrollOffset(m.offset, m.cursor, len(m.visible), page), page, len(m.visible)))
 
-	if len(m.rawData) > 0 && !m.menuOpen {
-		s += lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Render("\n  ↑/↓: Navigate  Enter: View README") + "\n"
+	if len(m.visible) > 0 {
+		s += m.renderTable() + "\n\n"
+	} else if len(m.rawData) > 0 {
+		s += hintStyle.Render("  No projects match the filter.") + "\n\n"
+	} else {
+		s += hintStyle.Render("  No projects or workspaces found.") + "\n\n"
 	}
 
-	return s
+	return s + m.footer()
 }
 
 func (m lipglossDashboardModel) renderTable() string {
+	columns := m.columns()
+	widths := fitColumns(columns, m.state.width)
 
-	const (
-		workspaceWidth = 12
-		projectWidth   = 15
-		statusWidth    = 10
-		lastModWidth   = 18
-		pathWidth      = 40
-	)
+	page := m.pageSize()
+	offset := scrollOffset(m.offset, m.cursor, len(m.visible), page)
+	end := min(offset+page, len(m.visible))
+	shown := m.visible[offset:end]
 
 	var rows [][]string
-	for _, data := range m.rawData {
+	for _, v := range shown {
+		data := m.rawData[v.index]
 
-		statusText := data.Status
-		if data.Status == "none" {
-			statusText = "none"
+		prefix := ""
+		if data.Status != "none" {
+			prefix = markedPrefix(m.marked, data.Project)
 		}
 
-		projectText := data.Project
-
 		lastMod := "none"
 		if data.Project != "none" {
 			lastMod = getLastModifiedTime(data.Path)
 		}
 
-		workspace := truncateString(data.Workspace, workspaceWidth)
-		project := truncateString(projectText, projectWidth)
-		path := truncateString(data.Path, pathWidth)
+		archiveText := ""
+		if data.Archive != "" {
+			archiveText = utils.FormatSize(archive.Size(data.Archive))
+			lastMod = "compacted"
+		}
 
 		rows = append(rows, []string{
-			workspace,
-			project,
-			statusText,
-			lastMod,
-			path,
-		})
-	}
-
-	t := table.New().
-		Border(lipgloss.RoundedBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(primaryColor)).
-		Headers("Workspace", "Project", "Status", "Last Modified", "Path").
-		Rows(rows...).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			var width int
-			switch col {
-			case 0:
-				width = workspaceWidth
-			case 1:
-				width = projectWidth
-			case 2:
-				width = statusWidth
-			case 3:
-				width = lastModWidth
-			case 4:
-				width = pathWidth
-			}
-
-			style := lipgloss.NewStyle().Width(width).Padding(0, 1)
-
-			if row == table.HeaderRow {
-				return style.
-					Bold(true).
-					Foreground(primaryColor).
-					BorderForeground(primaryColor).
-					Align(lipgloss.Left)
-			}
-
-			var rowColor lipgloss.Color
-			if row < len(m.rawData) {
-				switch m.rawData[row].Status {
-				case "active":
-					rowColor = successColor
-				case "archived":
-					rowColor = warningColor
-				case "done":
-					rowColor = secondaryColor
-				case "none":
-					rowColor = mutedColor
-				case "not set":
-					rowColor = mutedColor
-				default:
-					rowColor = whiteColor
-				}
-			} else {
-				rowColor = whiteColor
-			}
-
-			if row == m.cursor {
-				return style.
-					Background(primaryColor).
-					Foreground(whiteColor).
-					Bold(true)
-			}
-
-			return style.Foreground(rowColor)
+			highlightMatches(data.Workspace, cellMatch(v, fieldWorkspace), 0, max(widths[0]-2, 4)),
+			highlightMatches(prefix+data.Project, cellMatch(v, fieldName), len([]rune(prefix)), max(widths[1]-2, 4)),
+			highlightMatches(data.Status, cellMatch(v, fieldStatus), 0, max(widths[2]-2, 4)),
+			highlightMatches(strings.Join(data.Tags, " "), cellMatch(v, fieldTags), 0, max(widths[3]-2, 4)),
+			highlightMatches(data.Description, cellMatch(v, fieldDescription), 0, max(widths[4]-2, 4)),
+			cellText(formatWeekTime(m.state.weekTime(data.Path)), widths[5]),
+			cellText(lastMod, widths[6]),
+			cellText(archiveText, widths[7]),
+			cellText(data.Path, widths[8]),
 		})
-
-	return t.Render()
-}
-
-func selectDashboardLipgloss(workspaces []string, projects map[string]config.Project) (string, string) {
-	_, rawData := prepareDashboardData(workspaces, projects)
-
-	m := lipglossDashboardModel{
-		workspaces: workspaces,
-		projects:   projects,
-		rawData:    rawData,
-		cursor:     0,
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	finalModel, _ := p.Run()
-	result := finalModel.(lipglossDashboardModel)
-
-	return result.selected, result.action
+	return renderTable(columns, widths, rows, m.cursor-offset, func(row int) lipgloss.Style {
+		status := m.rawData[shown[row].index].Status
+		if status == "none" {
+			return lipgloss.NewStyle().Foreground(mutedColor)
+		}
+		return lipgloss.NewStyle().Foreground(statusColor(m.state.cfg, status))
+	})
 }
 
 func prepareDashboardData(workspaces []string, projects map[string]config.Project) ([][]string, []dashboardRow) {
@@ -289,14 +371,15 @@ func prepareDashboardData(workspaces []string, projects map[string]config.Projec
 		wProjects := 0
 		for _, p := range projects {
 			if strings.HasPrefix(p.Path, w) {
-				status := p.Status
-				if status == "" {
-					status = "not set"
-				}
+				status := config.StatusOf(p)
 				lastMod := getLastModifiedTime(p.Path)
 
+				if p.Archive != "" {
+					lastMod = "compacted"
+				}
+
 				rows = append(rows, []string{wName, p.Name, status, lastMod, p.Path})
-				rawData = append(rawData, dashboardRow{Workspace: wName, Project: p.Name, Status: status, Path: p.Path})
+				rawData = append(rawData, dashboardRow{Workspace: wName, Project: p.Name, Status: status, Path: p.Path, Archive: p.Archive, Alias: p.Alias, Tags: p.Tags, Description: p.Description})
 				wProjects++
 			}
 		}
@@ -308,3 +391,21 @@ func prepareDashboardData(workspaces []string, projects map[string]config.Projec
 
 	return rows, rawData
 }
+
+// selectorSummary describes the selector the dashboard was started with.
+func selectorSummary(sel config.Selector) string {
+	var parts []string
+	if sel.Workspace != "" {
+		parts = append(parts, "workspace "+sel.Workspace)
+	}
+	if sel.Status != "" {
+		parts = append(parts, "status "+sel.Status)
+	}
+	if sel.Tag != "" {
+		parts = append(parts, "tag "+sel.Tag)
+	}
+	if sel.Search != "" {
+		parts = append(parts, "search \""+sel.Search+"\"")
+	}
+	return strings.Join(parts, " · ")
+}
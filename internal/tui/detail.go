@@ -0,0 +1,395 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/theme"
+	"github.com/henrynguci/orbit/internal/utils"
+)
+
+// sideBySideWidth is the narrowest terminal that shows the details and the
+// README next to each other rather than stacked.
+const sideBySideWidth = 100
+
+// historyCount is how many status changes the details show.
+const historyCount = 5
+
+type projectInfoMsg struct {
+	path string
+	info projectInfo
+}
+
+// projectDetailModel shows everything orbit knows about one project, with
+// its README in a scrollable panel and the usual project actions.
+type projectDetailModel struct {
+	state   *appState
+	project config.Project
+	info    projectInfo
+	loaded  bool
+	spinner spinner.Model
+	readme  viewport.Model
+}
+
+func newProjectDetailScreen(state *appState, project config.Project) projectDetailModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
+
+	m := projectDetailModel{
+		state:   state,
+		project: project,
+		spinner: s,
+		readme:  viewport.New(0, 0),
+	}
+	m.layout()
+	return m
+}
+
+func (m projectDetailModel) Init() tea.Cmd {
+	return tea.Batch(m.spinner.Tick, m.load())
+}
+
+// load gathers the project info off the UI goroutine, since walking the repo
+// can take a moment.
+func (m projectDetailModel) load() tea.Cmd {
+	path := m.project.Path
+	return func() tea.Msg {
+		return projectInfoMsg{path: path, info: loadProjectInfo(path)}
+	}
+}
+
+func (m projectDetailModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.layout()
+		return m, nil
+
+	case configChangedMsg:
+		project, ok := m.findProject()
+		if !ok {
+			return m, popScreen
+		}
+		m.project = project
+		m.layout()
+		return m, m.load()
+
+	case projectInfoMsg:
+		if msg.path == m.project.Path {
+			m.info = msg.info
+			m.loaded = true
+			m.layout()
+		}
+		return m, nil
+
+	case spinner.TickMsg:
+		if m.loaded {
+			return m, nil
+		}
+		var cmd tea.Cmd
+		m.spinner, cmd = m.spinner.Update(msg)
+		return m, cmd
+
+	case tea.KeyMsg:
+		keys := m.state.keys
+		p := m.project
+		switch {
+		case keys.matches(msg, keyQuit):
+			return m, tea.Quit
+		case keys.matches(msg, keyBack):
+			return m, popScreen
+		case keys.matches(msg, keyHelp):
+			return m, pushScreen(newHelpScreen(m.state, "Project", helpGroup{
+				title:   "README",
+				actions: []string{keyUp, keyDown, keyPageUp, keyPageDown, keyTop, keyBottom},
+			}, helpGroup{
+				title:   "Project",
+				actions: []string{keyGoto, keyStatus, keyEdit, keyLink, keyNote, keyTrack, keyMenu, keyTasks, keyReadme, keyDelete, keyBack, keyHelp, keyQuit},
+			}))
+		case keys.matches(msg, keyGoto):
+			return m, gotoDirectory(m.state, p.Path)
+		case keys.matches(msg, keyStatus):
+			return m, pushScreen(handleChangeStatus(m.state, p.Name))
+		case keys.matches(msg, keyEdit):
+			return m, pushScreen(handleEditProject(m.state, p.Name))
+		case keys.matches(msg, keyLink):
+			return m, openProjectLink(m.state, p.Name)
+		case keys.matches(msg, keyNote):
+			return m, pushScreen(handleAddNote(m.state, p.Name))
+		case keys.matches(msg, keyTrack):
+			return m, toggleTracking(m.state, p.Name, p.Path)
+		case keys.matches(msg, keyTasks):
+			return m, pushScreen(handleRunTask(p.Path))
+		case keys.matches(msg, keyDelete):
+			return m, pushScreen(handleDeleteProject(m.state, p.Name))
+		case keys.matches(msg, keyReadme):
+			return m, showProjectReadme(p.Name, p.Path)
+		case keys.matches(msg, keyMenu):
+			return m, pushScreen(m.actionMenu())
+		case keys.matches(msg, keyTop):
+			m.readme.GotoTop()
+			return m, nil
+		case keys.matches(msg, keyBottom):
+			m.readme.GotoBottom()
+			return m, nil
+		}
+	}
+
+	var cmd tea.Cmd
+	m.readme, cmd = m.readme.Update(msg)
+	return m, cmd
+}
+
+// findProject looks the project up again after the config changed, so edits
+// made from this screen show up. Renames are followed by path.
+func (m projectDetailModel) findProject() (config.Project, bool) {
+	for _, p := range config.GetAllProjects(m.state.cfg) {
+		if p.Path == m.project.Path {
+			return p, true
+		}
+	}
+	return config.Project{}, false
+}
+
+func (m projectDetailModel) actionMenu() tea.Model {
+	items := actionMenuItems()
+	labels := make([]string, len(items))
+	for i, item := range items {
+		labels[i] = item.label
+	}
+
+	path := m.project.Path
+	return newSelectDialog("Open Project", subtitleStyle.Render(path), "Select action:", labels, func(i int) tea.Cmd {
+		return tea.Sequence(popScreen, runMenuAction(m.state, items[i].action, path))
+	})
+}
+
+func (m projectDetailModel) sideBySide() bool {
+	return screenWidth(m.state.width) >= sideBySideWidth
+}
+
+// panelWidths splits the screen between the details and the README.
+func (m projectDetailModel) panelWidths() (int, int) {
+	width := screenWidth(m.state.width)
+	if !m.sideBySide() {
+		return width, width
+	}
+	info := width * 2 / 5
+	return info, width - info
+}
+
+// panelHeight is the height available to the panels, borders included.
+func (m projectDetailModel) panelHeight() int {
+	height := m.state.height
+	if height <= 0 {
+		height = 40
+	}
+	// A line is kept free for toasts.
+	return height - lipgloss.Height(m.header()) - lipgloss.Height(m.footer()) - 1
+}
+
+// layout sizes the README viewport to whatever the details leave over.
+func (m *projectDetailModel) layout() {
+	infoWidth, readmeWidth := m.panelWidths()
+
+	height := m.panelHeight() - 2
+	if !m.sideBySide() {
+		height -= lipgloss.Height(m.infoPanel(infoWidth, 0))
+	}
+
+	m.readme.KeyMap = m.state.keys.viewport()
+	m.readme.Width = max(readmeWidth-4, 10)
+	m.readme.Height = max(height, 3)
+	m.readme.SetContent(m.readmeContent())
+}
+
+func (m projectDetailModel) readmeContent() string {
+	switch {
+	case !m.loaded:
+		return ""
+	case m.info.readmePath == "":
+		return subtitleStyle.Render("No README found.")
+	}
+	text := strings.ReplaceAll(m.info.readme, "\t", "    ")
+	return lipgloss.NewStyle().Width(m.readme.Width).Render(text)
+}
+
+func (m projectDetailModel) header() string {
+	return "\n" + renderTitle("Project: "+m.project.Name, m.state.width) + "\n"
+}
+
+func (m projectDetailModel) footer() string {
+	keys := m.state.keys
+	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
+		blueBtn.Render(keys.label(keyGoto)+" Goto"),
+		blueBtn.Render(keys.label(keyStatus)+" Status"),
+		blueBtn.Render(keys.label(keyEdit)+" Edit"),
+		blueBtn.Render(keys.label(keyLink)+" Link"),
+		blueBtn.Render(keys.label(keyNote)+" Note"),
+		greenBtn.Render(keys.label(keyMenu)+" Code"),
+		greenBtn.Render(keys.label(keyTasks)+" Tasks"),
+		greenBtn.Render(keys.label(keyReadme)+" Glow"),
+		redBtn.Render(keys.label(keyDelete)+" Delete"),
+		yellowBtn.Render(keys.label(keyBack)+" Return"),
+		purpleBtn.Render(keys.label(keyQuit)+" Quit"),
+	)
+	width := screenWidth(m.state.width)
+	hints := hintStyle.MaxWidth(width).Render("  " + strings.Join([]string{
+		keys.hint("Scroll README", keyUp, keyDown),
+		keys.hint("Page", keyPageUp, keyPageDown),
+		keys.hint("Top/Bottom", keyTop, keyBottom),
+		keys.hint("Help", keyHelp),
+	}, "  "))
+	return lipgloss.NewStyle().MaxWidth(width).Render(helpBar) + "\n" + hints
+}
+
+func (m projectDetailModel) View() string {
+	infoWidth, readmeWidth := m.panelWidths()
+
+	readmeTitle := sectionStyle.Render("README")
+	if m.loaded && m.info.readmePath != "" && m.readme.TotalLineCount() > m.readme.Height {
+		readmeTitle += subtitleStyle.Render(fmt.Sprintf("  %d%%", int(m.readme.ScrollPercent()*100)))
+	}
+	readme := panelStyle.
+		BorderForeground(primaryColor).
+		Width(readmeWidth - 2).
+		Render(readmeTitle + "\n" + m.readme.View())
+
+	var body string
+	if m.sideBySide() {
+		info := m.infoPanel(infoWidth, lipgloss.Height(readme)-2)
+		body = lipgloss.JoinHorizontal(lipgloss.Top, info, readme)
+	} else {
+		body = m.infoPanel(infoWidth, 0) + "\n" + readme
+	}
+
+	return m.header() + body + "\n" + m.footer()
+}
+
+// infoPanel renders the project details. When height is positive the panel
+// is padded or cut to that many lines of content.
+func (m projectDetailModel) infoPanel(width, height int) string {
+	lines := m.infoLines(width - 4)
+	if height > 0 {
+		if len(lines) > height {
+			lines = lines[:height]
+		}
+		for len(lines) < height {
+			lines = append(lines, "")
+		}
+	}
+	return panelStyle.Width(width - 2).Render(strings.Join(lines, "\n"))
+}
+
+func (m projectDetailModel) infoLines(width int) []string {
+	p := m.project
+	field := func(label, value string) string {
+		return labelStyle.Render(label) + truncateString(value, max(width-10, 4))
+	}
+	orNone := func(value string) string {
+		if value == "" {
+			return subtitleStyle.Render("none")
+		}
+		return value
+	}
+
+	status := renderStatus(m.state.cfg, config.StatusOf(p))
+	if spans := config.StatusSpans(p, time.Now()); len(spans) > 0 {
+		status += subtitleStyle.Render("  for " + utils.FormatDuration(spans[len(spans)-1].Duration()))
+	}
+
+	lines := []string{
+		field("About", orNone(p.Description)),
+		field("Alias", orNone(p.Alias)),
+		field("Status", status),
+		field("Tags", orNone(strings.Join(p.Tags, ", "))),
+	}
+	if p.Archive != "" {
+		lines = append(lines, field("Archive", p.Archive))
+	}
+	lines = append(lines, field("Path", p.Path))
+
+	if names := config.LinkNames(p); len(names) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Links"))
+		for _, name := range names {
+			lines = append(lines, field(truncateString(name, 9), p.Links[name]))
+		}
+	}
+
+	if len(p.History) > 0 {
+		lines = append(lines, "", sectionStyle.Render("History"))
+		start := max(len(p.History)-historyCount, 0)
+		for i := len(p.History) - 1; i >= start; i-- {
+			c := p.History[i]
+			from := c.From
+			if from == "" {
+				from = config.NotSet
+			}
+			line := subtitleStyle.Render(timeAgo(c.At)) + " " + from + " → " + renderStatus(m.state.cfg, c.To)
+			if c.Note != "" {
+				line += subtitleStyle.Render("  " + c.Note)
+			}
+			lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(line))
+		}
+	}
+
+	if !m.loaded {
+		return append(lines, "", m.spinner.View()+" reading project...")
+	}
+	info := m.info
+
+	if len(info.journal) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Journal"))
+		for _, e := range info.journal {
+			text, _, _ := strings.Cut(e.Text, "\n")
+			lines = append(lines, lipgloss.NewStyle().MaxWidth(width).Render(subtitleStyle.Render(timeAgo(e.Time))+" "+text))
+		}
+	}
+
+	lines = append(lines, "", sectionStyle.Render("Git"))
+	if !info.hasRepo {
+		lines = append(lines, subtitleStyle.Render("Not a git repository."))
+	} else {
+		branch := orNone(info.branch)
+		if info.changed > 0 {
+			branch += lipgloss.NewStyle().Foreground(warningColor).Render(fmt.Sprintf("  %d changed", info.changed))
+		} else if info.branch != "" {
+			branch += lipgloss.NewStyle().Foreground(successColor).Render("  clean")
+		}
+		lines = append(lines, field("Branch", branch), field("Remote", orNone(info.remote)))
+
+		for _, c := range info.commits {
+			when := " " + timeAgo(c.when)
+			subject := truncateString(c.subject, max(width-len(c.hash)-len(when)-1, 4))
+			lines = append(lines, lipgloss.NewStyle().Foreground(secondaryColor).Render(c.hash)+" "+subject+subtitleStyle.Render(when))
+		}
+	}
+
+	lines = append(lines, "", sectionStyle.Render("Sizes"),
+		field("docs/", utils.FormatSize(info.docsSize)),
+		field("secret/", utils.FormatSize(info.secretSize)),
+	)
+
+	if len(info.languages) > 0 {
+		lines = append(lines, "", sectionStyle.Render("Languages"))
+		barWidth := max(min(width-10-6, 20), 4)
+		full, empty := "█", "░"
+		if theme.Current().ASCII {
+			full, empty = "#", "-"
+		}
+		for _, l := range info.languages {
+			filled := int(l.percent/100*float64(barWidth) + 0.5)
+			bar := lipgloss.NewStyle().Foreground(primaryColor).Render(strings.Repeat(full, filled)) +
+				subtitleStyle.Render(strings.Repeat(empty, barWidth-filled))
+			lines = append(lines, labelStyle.Render(l.name)+bar+fmt.Sprintf(" %3.0f%%", l.percent))
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,247 @@
+package tui
+
+import (
+	"strings"
+
+	"github.com/charmbracelet/bubbles/spinner"
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type dialogKind int
+
+const (
+	dialogInput dialogKind = iota
+	dialogConfirm
+	dialogSelect
+	dialogMessage
+	dialogProgress
+)
+
+type progressDoneMsg struct {
+	err error
+}
+
+// dialogModel is a screen pushed on top of the stack for a single question.
+// Its callback decides what comes next: another dialog via replaceScreen, or
+// closeDialog to return to the screen underneath.
+type dialogModel struct {
+	kind    dialogKind
+	title   string
+	body    string
+	input   inputModel
+	confirm confirmModel
+	list    selectModel
+	spinner spinner.Model
+	isError bool
+	width   int
+
+	onInput   func(string) tea.Cmd
+	onConfirm func(bool) tea.Cmd
+	onSelect  func([]int) tea.Cmd
+	work      func() error
+	onDone    func(error) tea.Cmd
+}
+
+func newInputDialog(title, body, placeholder, header string, validate func(string) error, onInput func(string) tea.Cmd) dialogModel {
+	ti := textinput.New()
+	ti.Placeholder = placeholder
+	ti.Prompt = "> "
+	ti.PromptStyle = promptCursorStyle
+	ti.Focus()
+
+	return dialogModel{
+		kind:    dialogInput,
+		title:   title,
+		body:    body,
+		input:   inputModel{header: header, input: ti, validate: validate},
+		onInput: onInput,
+	}
+}
+
+func newPathDialog(title, body, placeholder, header string, validate func(string) error, onInput func(string) tea.Cmd) dialogModel {
+	d := newInputDialog(title, body, placeholder, header, validate, onInput)
+	d.input.completion = true
+	return d
+}
+
+func newConfirmDialog(title, body, prompt string, onConfirm func(bool) tea.Cmd) dialogModel {
+	return dialogModel{
+		kind:      dialogConfirm,
+		title:     title,
+		body:      body,
+		confirm:   confirmModel{prompt: prompt, affirmative: true},
+		onConfirm: onConfirm,
+	}
+}
+
+func newSelectDialog(title, body, header string, items []string, onSelect func(int) tea.Cmd) dialogModel {
+	return dialogModel{
+		kind:  dialogSelect,
+		title: title,
+		body:  body,
+		list:  selectModel{header: header, items: items, checked: make(map[int]bool)},
+		onSelect: func(indexes []int) tea.Cmd {
+			return onSelect(indexes[0])
+		},
+	}
+}
+
+func newMultiSelectDialog(title, body, header string, items []string, onSelect func([]int) tea.Cmd) dialogModel {
+	checked := make(map[int]bool)
+	for i := range items {
+		checked[i] = true
+	}
+
+	return dialogModel{
+		kind:     dialogSelect,
+		title:    title,
+		body:     body,
+		list:     selectModel{header: header, items: items, multi: true, checked: checked},
+		onSelect: onSelect,
+	}
+}
+
+func newMessageDialog(title, body string) dialogModel {
+	return dialogModel{kind: dialogMessage, title: title, body: body}
+}
+
+func newErrorDialog(title, body string) dialogModel {
+	d := newMessageDialog(title, body)
+	d.isError = true
+	return d
+}
+
+// newProgressDialog runs work off the UI goroutine and hands its result to
+// onDone, which runs back on the UI goroutine and may touch shared state.
+func newProgressDialog(title, body string, work func() error, onDone func(error) tea.Cmd) dialogModel {
+	s := spinner.New()
+	s.Spinner = spinner.Dot
+	s.Style = lipgloss.NewStyle().Foreground(primaryColor)
+
+	return dialogModel{
+		kind:    dialogProgress,
+		title:   title,
+		body:    body,
+		spinner: s,
+		work:    work,
+		onDone:  onDone,
+	}
+}
+
+// cancelDialog pops the dialog without reporting anything. Earlier steps of a
+// flow may already have written the config, so screens still reload.
+func cancelDialog() tea.Cmd {
+	return tea.Sequence(popScreen, reloadConfig)
+}
+
+func (m dialogModel) Init() tea.Cmd {
+	switch m.kind {
+	case dialogInput:
+		return m.input.Init()
+	case dialogProgress:
+		work := m.work
+		return tea.Batch(m.spinner.Tick, func() tea.Msg {
+			return progressDoneMsg{err: work()}
+		})
+	}
+	return nil
+}
+
+func (m dialogModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.WindowSizeMsg); ok {
+		m.width = msg.Width
+		return m, nil
+	}
+
+	switch m.kind {
+	case dialogInput:
+		updated, cmd := m.input.Update(msg)
+		m.input = updated.(inputModel)
+		switch {
+		case m.input.cancelled:
+			return m, cancelDialog()
+		case m.input.done:
+			return m, m.onInput(strings.TrimSpace(m.input.input.Value()))
+		}
+		return m, cmd
+
+	case dialogConfirm:
+		updated, cmd := m.confirm.Update(msg)
+		m.confirm = updated.(confirmModel)
+		switch {
+		case m.confirm.cancelled:
+			return m, cancelDialog()
+		case m.confirm.done:
+			return m, m.onConfirm(m.confirm.affirmative)
+		}
+		return m, cmd
+
+	case dialogSelect:
+		updated, cmd := m.list.Update(msg)
+		m.list = updated.(selectModel)
+		switch {
+		case m.list.cancelled:
+			return m, cancelDialog()
+		case m.list.done:
+			indexes := []int{m.list.cursor}
+			if m.list.multi {
+				indexes = m.list.selectedIndexes()
+			}
+			if len(m.list.items) == 0 || len(indexes) == 0 {
+				return m, cancelDialog()
+			}
+			return m, m.onSelect(indexes)
+		}
+		return m, cmd
+
+	case dialogMessage:
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			switch msg.String() {
+			case "enter", "esc", "r", "q", "ctrl+c":
+				return m, cancelDialog()
+			}
+		}
+
+	case dialogProgress:
+		switch msg := msg.(type) {
+		case progressDoneMsg:
+			return m, m.onDone(msg.err)
+		case spinner.TickMsg:
+			var cmd tea.Cmd
+			m.spinner, cmd = m.spinner.Update(msg)
+			return m, cmd
+		}
+	}
+
+	return m, nil
+}
+
+func (m dialogModel) View() string {
+	var s strings.Builder
+	s.WriteString("\n" + renderTitle(m.title, m.width) + "\n")
+
+	if m.body != "" {
+		style := lipgloss.NewStyle()
+		if m.isError {
+			style = style.Foreground(errorColor)
+		}
+		s.WriteString(style.Render(m.body) + "\n\n")
+	}
+
+	switch m.kind {
+	case dialogInput:
+		s.WriteString(m.input.View())
+	case dialogConfirm:
+		s.WriteString(m.confirm.View())
+	case dialogSelect:
+		s.WriteString(m.list.View())
+	case dialogMessage:
+		s.WriteString(yellowBtn.Render("r Return") + "\n")
+	case dialogProgress:
+		s.WriteString(m.spinner.View() + " working...\n")
+	}
+
+	return s.String()
+}
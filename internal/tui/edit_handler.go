@@ -0,0 +1,175 @@
+package tui
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/journal"
+	"github.com/henrynguci/orbit/internal/utils"
+)
+
+// findProject looks a project up by name, falling back to the projects found
+// in the workspaces that are not in the config yet.
+func findProject(cfg *config.Config, projectName string) (config.Project, bool) {
+	if project, exists := cfg.Projects[projectName]; exists {
+		return project, true
+	}
+	for _, p := range config.GetAllProjects(cfg) {
+		if p.Name == projectName {
+			return p, true
+		}
+	}
+	return config.Project{}, false
+}
+
+func handleEditProject(state *appState, projectName string) tea.Model {
+	cfg := state.cfg
+
+	project, exists := findProject(cfg, projectName)
+	if !exists {
+		return newErrorDialog("Edit Project", "Project not found.")
+	}
+	project.Name = projectName
+
+	body := fmt.Sprintf("Project: %s", lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(projectName))
+	fields := []string{"Description", "Tags", "Links"}
+
+	return newSelectDialog("Edit Project", body, "Select what to edit:", fields, func(i int) tea.Cmd {
+		switch fields[i] {
+		case "Description":
+			d := newInputDialog("Edit Project", body, "one line about the project", "Description:", nil, func(text string) tea.Cmd {
+				project.Description = strings.Join(strings.Fields(text), " ")
+				return saveProjectEdit(cfg, project, fmt.Sprintf("Description of '%s' updated", projectName))
+			})
+			d.input.input.SetValue(project.Description)
+			return replaceScreen(d)
+		case "Tags":
+			d := newInputDialog("Edit Project", body, "go, cli", "Tags (comma-separated):", nil, func(text string) tea.Cmd {
+				project.Tags = nil
+				for _, t := range strings.Split(text, ",") {
+					config.AddTags(&project, strings.TrimSpace(t))
+				}
+				return saveProjectEdit(cfg, project, fmt.Sprintf("Tags of '%s' updated", projectName))
+			})
+			d.input.input.SetValue(strings.Join(project.Tags, ", "))
+			return replaceScreen(d)
+		default:
+			return replaceScreen(editLinksDialog(cfg, project, body))
+		}
+	})
+}
+
+// editLinksDialog lists the links of a project to change or remove one, or
+// to add a new one.
+func editLinksDialog(cfg *config.Config, project config.Project, body string) tea.Model {
+	names := config.LinkNames(project)
+	items := make([]string, 0, len(names)+1)
+	for _, name := range names {
+		items = append(items, fmt.Sprintf("%s  %s", name, project.Links[name]))
+	}
+	items = append(items, "Add a link")
+
+	return newSelectDialog("Edit Project", body, "Select a link:", items, func(i int) tea.Cmd {
+		if project.Links == nil {
+			project.Links = make(map[string]string)
+		}
+
+		if i == len(names) {
+			validate := func(text string) error {
+				if len(strings.Fields(text)) != 2 {
+					return fmt.Errorf("enter a name and a URL")
+				}
+				return nil
+			}
+			return replaceScreen(newInputDialog("Edit Project", body, "site https://example.com", "Name and URL:", validate, func(text string) tea.Cmd {
+				fields := strings.Fields(text)
+				project.Links[fields[0]] = fields[1]
+				return saveProjectEdit(cfg, project, fmt.Sprintf("Link '%s' set for '%s'", fields[0], project.Name))
+			}))
+		}
+
+		name := names[i]
+		header := fmt.Sprintf("URL for '%s' (empty removes it):", name)
+		d := newInputDialog("Edit Project", body, "https://example.com", header, nil, func(url string) tea.Cmd {
+			if url == "" {
+				delete(project.Links, name)
+				return saveProjectEdit(cfg, project, fmt.Sprintf("Link '%s' removed from '%s'", name, project.Name))
+			}
+			project.Links[name] = url
+			return saveProjectEdit(cfg, project, fmt.Sprintf("Link '%s' set for '%s'", name, project.Name))
+		})
+		d.input.input.SetValue(project.Links[name])
+		return replaceScreen(d)
+	})
+}
+
+func saveProjectEdit(cfg *config.Config, project config.Project, msg string) tea.Cmd {
+	config.UpdateProject(cfg, project)
+	if err := config.Save(cfg); err != nil {
+		return closeDialog(showErrorToast(fmt.Sprintf("Failed to save config: %v", err)))
+	}
+	return closeDialog(showToast(msg))
+}
+
+// openProjectLink opens the link of a project in the browser, asking which
+// one when there are several.
+func openProjectLink(state *appState, projectName string) tea.Cmd {
+	project, exists := findProject(state.cfg, projectName)
+	names := config.LinkNames(project)
+	if !exists || len(names) == 0 {
+		return showErrorToast(fmt.Sprintf("Project '%s' has no links", projectName))
+	}
+	if len(names) == 1 {
+		return openURL(project.Links[names[0]])
+	}
+
+	items := make([]string, len(names))
+	for i, name := range names {
+		items[i] = fmt.Sprintf("%s  %s", name, project.Links[name])
+	}
+	return pushScreen(newSelectDialog("Open Link", "", "Select a link:", items, func(i int) tea.Cmd {
+		return tea.Sequence(popScreen, openURL(project.Links[names[i]]))
+	}))
+}
+
+func openURL(url string) tea.Cmd {
+	if err := utils.OpenURL(url); err != nil {
+		return showErrorToast(fmt.Sprintf("Failed to open %s: %v", url, err))
+	}
+	return showToast(fmt.Sprintf("Opened %s", url))
+}
+
+// handleAddNote asks for a note and appends it to the project's journal.
+// Compacted projects and projects whose directory is gone have no journal to
+// write to.
+func handleAddNote(state *appState, projectName string) tea.Model {
+	project, exists := findProject(state.cfg, projectName)
+	if !exists {
+		return newErrorDialog("Journal", fmt.Sprintf("Project '%s' not found", projectName))
+	}
+	if project.Archive != "" {
+		return newErrorDialog("Journal", fmt.Sprintf("Project '%s' is compacted. Restore it before adding notes.", projectName))
+	}
+	if _, err := os.Stat(project.Path); err != nil {
+		return newErrorDialog("Journal", fmt.Sprintf("Directory '%s' does not exist", project.Path))
+	}
+
+	body := fmt.Sprintf("Project: %s", lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(projectName))
+	validate := func(text string) error {
+		if strings.TrimSpace(text) == "" {
+			return fmt.Errorf("enter a note")
+		}
+		return nil
+	}
+	return newInputDialog("Journal", body, "where you left off, what comes next", "Note:", validate, func(text string) tea.Cmd {
+		if err := journal.Append(project.Path, text, time.Now()); err != nil {
+			return closeDialog(showErrorToast(fmt.Sprintf("Failed to write the journal: %v", err)))
+		}
+		return closeDialog(showToast(fmt.Sprintf("Added a note to the journal of '%s'", projectName)))
+	})
+}
@@ -0,0 +1,217 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// Fields a row can be matched on, in the order they are tried. Workspace rows
+// only have a name.
+const (
+	fieldName = iota
+	fieldAlias
+	fieldWorkspace
+	fieldStatus
+	fieldTags
+	fieldDescription
+)
+
+// tableFilter is the "/" filter shared by the workspace, project and
+// dashboard tables.
+type tableFilter struct {
+	input  textinput.Model
+	typing bool
+}
+
+// filteredRow points at a row of the unfiltered data and records which field
+// matched, so the table can highlight it.
+type filteredRow struct {
+	index     int
+	field     int
+	positions []int
+}
+
+func newTableFilter() tableFilter {
+	ti := textinput.New()
+	ti.Prompt = "/"
+	ti.PromptStyle = promptCursorStyle
+	return tableFilter{input: ti}
+}
+
+func (f tableFilter) query() string {
+	return strings.TrimSpace(f.input.Value())
+}
+
+func (f tableFilter) active() bool {
+	return f.typing || f.query() != ""
+}
+
+// update handles a key press for the filter. It reports whether the key was
+// consumed; keys that are not are handled by the table as usual. While typing,
+// only the arrow keys and bindings that cannot be typed reach the table.
+func (f tableFilter) update(keys keyMap, msg tea.KeyMsg) (tableFilter, bool, tea.Cmd) {
+	if !f.typing {
+		switch {
+		case keys.matches(msg, keyFilter):
+			f.typing = true
+			return f, true, f.input.Focus()
+		case msg.String() == "esc" && f.query() != "":
+			f.input.SetValue("")
+			return f, true, nil
+		}
+		return f, false, nil
+	}
+
+	switch msg.String() {
+	case "enter":
+		f.typing = false
+		f.input.Blur()
+		return f, true, nil
+	case "esc":
+		f.typing = false
+		f.input.Blur()
+		f.input.SetValue("")
+		return f, true, nil
+	case "up", "down", "ctrl+c":
+		return f, false, nil
+	}
+	if msg.Type != tea.KeyRunes && (keys.matches(msg, keyUp) || keys.matches(msg, keyDown)) {
+		return f, false, nil
+	}
+
+	var cmd tea.Cmd
+	f.input, cmd = f.input.Update(msg)
+	return f, true, cmd
+}
+
+// apply returns the rows whose fields match the query. Every row matches an
+// empty query.
+func (f tableFilter) apply(count int, fields func(int) []string) []filteredRow {
+	query := f.query()
+
+	rows := make([]filteredRow, 0, count)
+	for i := 0; i < count; i++ {
+		if query == "" {
+			rows = append(rows, filteredRow{index: i, field: -1})
+			continue
+		}
+		if field, positions, ok := matchFields(query, fields(i)); ok {
+			rows = append(rows, filteredRow{index: i, field: field, positions: positions})
+		}
+	}
+	return rows
+}
+
+func (f tableFilter) view(shown, total int, noun string) string {
+	if !f.active() {
+		return ""
+	}
+
+	count := filterStyle.Render(fmt.Sprintf("  %d of %d %s", shown, total, noun))
+	if f.typing {
+		return "  " + f.input.View() + count + "\n"
+	}
+	return "  " + promptCursorStyle.Render("/") + f.query() + count + promptHintStyle.Render("  esc clear") + "\n"
+}
+
+func projectFields(p config.Project, workspace string) []string {
+	return []string{
+		fieldName:        p.Name,
+		fieldAlias:       p.Alias,
+		fieldWorkspace:   workspace,
+		fieldStatus:      config.StatusOf(p),
+		fieldTags:        strings.Join(p.Tags, " "),
+		fieldDescription: p.Description,
+	}
+}
+
+// matchFields tries each field in turn and returns the first that matches.
+func matchFields(pattern string, fields []string) (int, []int, bool) {
+	for i, field := range fields {
+		if positions, ok := fuzzyMatch(pattern, field); ok {
+			return i, positions, true
+		}
+	}
+	return 0, nil, false
+}
+
+// fuzzyMatch reports whether the runes of pattern appear in text in order,
+// ignoring case, and returns the rune positions that matched. A contiguous
+// match is preferred so that "orb" highlights "orbit" rather than scattered
+// letters.
+func fuzzyMatch(pattern, text string) ([]int, bool) {
+	p := []rune(strings.ToLower(pattern))
+	t := []rune(strings.ToLower(text))
+	if len(p) == 0 {
+		return nil, true
+	}
+
+	for start := 0; start+len(p) <= len(t); start++ {
+		if string(t[start:start+len(p)]) == string(p) {
+			positions := make([]int, len(p))
+			for i := range p {
+				positions[i] = start + i
+			}
+			return positions, true
+		}
+	}
+
+	var positions []int
+	j := 0
+	for i, r := range t {
+		if j < len(p) && r == p[j] {
+			positions = append(positions, i)
+			j++
+		}
+	}
+	if j < len(p) {
+		return nil, false
+	}
+	return positions, true
+}
+
+// highlightMatches truncates text to maxLen and renders the matched runes in
+// the match style. offset shifts positions when text carries a prefix that
+// was not part of the match.
+func highlightMatches(text string, positions []int, offset int, maxLen int) string {
+	if strings.Contains(text, "\x1b[") {
+		return text
+	}
+
+	runes := []rune(text)
+	limit := len(runes)
+	if len(runes) > maxLen {
+		limit = maxLen - 3
+		runes = append(runes[:limit], []rune("...")...)
+	}
+	if len(positions) == 0 {
+		return string(runes)
+	}
+
+	matched := make(map[int]bool, len(positions))
+	for _, pos := range positions {
+		matched[pos+offset] = true
+	}
+
+	var s strings.Builder
+	for i, r := range runes {
+		if matched[i] && i < limit {
+			s.WriteString(matchStyle.Render(string(r)))
+			continue
+		}
+		s.WriteRune(r)
+	}
+	return s.String()
+}
+
+// cellMatch returns the positions to highlight in a column showing field.
+func cellMatch(row filteredRow, field int) []int {
+	if row.field != field {
+		return nil
+	}
+	return row.positions
+}
@@ -0,0 +1,98 @@
+package tui
+
+import (
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+// helpGroup is a titled list of actions for the help overlay.
+type helpGroup struct {
+	title   string
+	actions []string
+}
+
+// navigationHelp is the group shared by every table.
+var navigationHelp = helpGroup{
+	title:   "Navigation",
+	actions: []string{keyUp, keyDown, keyPageUp, keyPageDown, keyTop, keyBottom, keyFilter, keySort, keyReverse},
+}
+
+// projectHelp is the group for tables of projects. extra actions are listed
+// right after opening a project.
+func projectHelp(extra ...string) helpGroup {
+	actions := append([]string{keySelect}, extra...)
+	actions = append(actions, keyDelete, keyStatus, keyEdit, keyLink, keyNote, keyTrack, keyMark, keyGoto, keyTasks, keyMenu, keyUndo, keyBack, keyHelp, keyQuit)
+	return helpGroup{title: "Projects", actions: actions}
+}
+
+// tableHints is the hint line under the project tables.
+func tableHints(keys keyMap) string {
+	return strings.Join([]string{
+		keys.hint("Navigate", keyUp, keyDown),
+		keys.hint("Page", keyPageUp, keyPageDown),
+		keys.hint("Details", keySelect),
+		keys.hint("Select", keyMark),
+		keys.hint("Filter", keyFilter),
+		keys.hint("Sort", keySort, keyReverse),
+		keys.hint("Help", keyHelp),
+	}, "  ")
+}
+
+// helpModel lists the bindings available on the screen underneath it. Any
+// key closes it.
+type helpModel struct {
+	state  *appState
+	title  string
+	groups []helpGroup
+}
+
+func newHelpScreen(state *appState, title string, groups ...helpGroup) helpModel {
+	return helpModel{state: state, title: title, groups: groups}
+}
+
+func (m helpModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m helpModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if _, ok := msg.(tea.KeyMsg); ok {
+		return m, popScreen
+	}
+	return m, nil
+}
+
+func (m helpModel) View() string {
+	keys := m.state.keys
+
+	width := 0
+	for _, g := range m.groups {
+		for _, action := range g.actions {
+			width = max(width, lipgloss.Width(keys[action].Help().Key))
+		}
+	}
+	keyStyle := lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Width(width + 3)
+
+	var columns []string
+	for _, g := range m.groups {
+		lines := []string{sectionStyle.Render(g.title)}
+		for _, action := range g.actions {
+			help := keys[action].Help()
+			lines = append(lines, keyStyle.Render(help.Key)+help.Desc)
+		}
+		columns = append(columns, lipgloss.NewStyle().MarginRight(4).Render(strings.Join(lines, "\n")))
+	}
+
+	box := boxStyle.Render(
+		promptHeaderStyle.Render("Keys — "+m.title) + "\n\n" +
+			lipgloss.JoinHorizontal(lipgloss.Top, columns...) + "\n\n" +
+			promptHintStyle.Render("Press any key to close"),
+	)
+
+	height := m.state.height
+	if height <= 0 {
+		return box
+	}
+	return lipgloss.Place(screenWidth(m.state.width), height-1, lipgloss.Center, lipgloss.Center, box)
+}
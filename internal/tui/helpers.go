@@ -3,8 +3,17 @@ package tui
 import (
 	"fmt"
 	"os"
+	"sort"
 	"strings"
 	"time"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/plugins"
+	"github.com/henrynguci/orbit/internal/theme"
+	"github.com/henrynguci/orbit/internal/utils"
 )
 
 func getLastModifiedTime(path string) string {
@@ -27,6 +36,33 @@ func getLastModifiedTime(path string) string {
 	return modTime.Format("02/01/2006 15:04")
 }
 
+// statusColor is the colour the config gives a status. Statuses that are not
+// configured use the text colour.
+func statusColor(cfg *config.Config, status string) lipgloss.TerminalColor {
+	if s, ok := config.LookupStatus(cfg, status); ok {
+		return theme.Named(s.Color)
+	}
+	return textColor
+}
+
+// renderStatus draws a status with its colour and icon.
+func renderStatus(cfg *config.Config, status string) string {
+	label := status
+	if s, ok := config.LookupStatus(cfg, status); ok {
+		label = s.Label()
+	}
+	return lipgloss.NewStyle().Foreground(statusColor(cfg, status)).Render(label)
+}
+
+// formatWeekTime is the "Week" cell of the project tables, empty when no
+// time was tracked.
+func formatWeekTime(d time.Duration) string {
+	if d == 0 {
+		return ""
+	}
+	return utils.FormatHours(d)
+}
+
 func min(a, b int) int {
 	if a < b {
 		return a
@@ -34,6 +70,16 @@ func min(a, b int) int {
 	return b
 }
 
+func clampCursor(cursor, length int) int {
+	if cursor >= length {
+		cursor = length - 1
+	}
+	if cursor < 0 {
+		cursor = 0
+	}
+	return cursor
+}
+
 func truncateString(s string, maxLen int) string {
 	if strings.Contains(s, "\x1b[") {
 		return s
@@ -44,9 +90,116 @@ func truncateString(s string, maxLen int) string {
 	return s
 }
 
+func toggleMarked(marked map[string]bool, name string) {
+	if marked[name] {
+		delete(marked, name)
+	} else {
+		marked[name] = true
+	}
+}
+
+func markedPrefix(marked map[string]bool, name string) string {
+	if marked[name] {
+		return "● "
+	}
+	return ""
+}
+
+func markedNames(marked map[string]bool) []string {
+	names := make([]string, 0, len(marked))
+	for name := range marked {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+func markedSummary(keys keyMap, count int) string {
+	return fmt.Sprintf("  %d selected — press %s to change status of all", count, keys.label(keyStatus))
+}
+
+type menuItem struct {
+	label  string
+	action string
+}
+
+func actionMenuItems() []menuItem {
+	items := []menuItem{
+		{label: "open with code", action: "code_code"},
+		{label: "open with cursor", action: "code_cursor"},
+		{label: "open with antigravityy", action: "code_antigravityy"},
+	}
+
+	for _, p := range plugins.List() {
+		for i, entry := range p.Manifest.Menu {
+			items = append(items, menuItem{
+				label:  entry.Label,
+				action: fmt.Sprintf("plugin_%s:%d", p.Name, i),
+			})
+		}
+	}
+
+	return items
+}
+
+// updateMenu handles a key while the action menu is open and returns whether
+// it stays open, the new selection and the command of a chosen action.
+func updateMenu(state *appState, msg tea.KeyMsg, items []menuItem, index int, path string) (bool, int, tea.Cmd) {
+	keys := state.keys
+	switch {
+	case keys.matches(msg, keyUp):
+		if index > 0 {
+			index--
+		}
+	case keys.matches(msg, keyDown):
+		if index < len(items)-1 {
+			index++
+		}
+	case keys.matches(msg, keySelect):
+		return false, index, runMenuAction(state, items[index].action, path)
+	case keys.matches(msg, keyBack), keys.matches(msg, keyQuit):
+		return false, index, nil
+	}
+	return true, index, nil
+}
+
+func renderMenu(items []menuItem, index int) string {
+	var menuLines []string
+	for i, item := range items {
+		line := "  " + item.label
+		if i == index {
+			line = " > " + lipgloss.NewStyle().Foreground(primaryColor).Bold(true).Render(item.label)
+		}
+		menuLines = append(menuLines, line)
+	}
+
+	menuBox := lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(primaryColor).
+		Padding(1, 2).
+		Render(lipgloss.JoinVertical(lipgloss.Left, menuLines...))
+
+	return lipgloss.NewStyle().MarginLeft(4).Render(menuBox)
+}
+
+func runMenuAction(state *appState, action string, path string) tea.Cmd {
+	switch {
+	case strings.HasPrefix(action, "code_"):
+		return openWithTool(state, path, strings.TrimPrefix(action, "code_"))
+	case strings.HasPrefix(action, "plugin_"):
+		return runPluginMenuEntry(state.cfg, path, strings.TrimPrefix(action, "plugin_"))
+	}
+	return nil
+}
+
 type dashboardRow struct {
 	Workspace string
 	Project   string
 	Status    string
 	Path      string
+	Archive   string
+	Alias     string
+	Tags      []string
+	// Description is the project's one-line summary.
+	Description string
 }
@@ -0,0 +1,130 @@
+package tui
+
+import (
+	"fmt"
+	"os/exec"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/frecency"
+	"github.com/henrynguci/orbit/internal/hooks"
+	"github.com/henrynguci/orbit/internal/timelog"
+)
+
+func projectEvent(cfg *config.Config, name string, path string) hooks.Event {
+	ev := hooks.Event{Path: path}
+	if cfg == nil {
+		return ev
+	}
+
+	if name == "" {
+		for _, p := range config.GetAllProjects(cfg) {
+			if p.Path == path {
+				name = p.Name
+				break
+			}
+		}
+	}
+	ev.Project = name
+
+	if project, exists := cfg.Projects[name]; exists {
+		ev.Status = project.Status
+		ev.Workspace = config.WorkspaceOf(cfg, project)
+	} else {
+		ev.Workspace = config.WorkspaceOf(cfg, config.Project{Path: path})
+	}
+
+	return ev
+}
+
+// runPreHook returns a dialog explaining the veto, or nil when the event may
+// go ahead.
+func runPreHook(state *appState, ev hooks.Event) tea.Model {
+	state.hookOutput.Reset()
+	if err := hooks.Pre(state.cfg, ev); err != nil {
+		return newErrorDialog("Hook Failed", err.Error()+hookOutputTail(state))
+	}
+	return nil
+}
+
+func runPostHook(state *appState, ev hooks.Event) error {
+	state.hookOutput.Reset()
+	return hooks.Post(state.cfg, ev)
+}
+
+// hookOutputTail returns the last lines hooks printed while the TUI owned the
+// terminal, so a veto can be explained.
+func hookOutputTail(state *appState) string {
+	out := strings.TrimSpace(state.hookOutput.String())
+	if out == "" {
+		return ""
+	}
+
+	lines := strings.Split(out, "\n")
+	if len(lines) > 10 {
+		lines = lines[len(lines)-10:]
+	}
+	return "\n\n" + strings.Join(lines, "\n")
+}
+
+// doneToast reports success, or the post hook failure if there was one.
+func doneToast(text string, postErr error) tea.Cmd {
+	if postErr != nil {
+		return showErrorToast(postErr.Error())
+	}
+	return showToast(text)
+}
+
+func gotoDirectory(state *appState, path string) tea.Cmd {
+	ev := projectEvent(state.cfg, "", path)
+	ev.Name = hooks.EventOpen
+	ev.Tool = "shell"
+	if ev.Project == "" {
+		ev.Path = ""
+		ev.Workspace = path
+	}
+
+	if veto := runPreHook(state, ev); veto != nil {
+		return pushScreen(veto)
+	}
+	if ev.Project != "" {
+		frecency.Visit(path)
+		autoTrack(state, ev.Project, path)
+	}
+
+	return func() tea.Msg { return gotoMsg{path: path, event: ev} }
+}
+
+func openWithTool(state *appState, path string, tool string) tea.Cmd {
+	ev := projectEvent(state.cfg, "", path)
+	ev.Name = hooks.EventOpen
+	ev.Tool = tool
+
+	if veto := runPreHook(state, ev); veto != nil {
+		return pushScreen(veto)
+	}
+	if ev.Project != "" {
+		frecency.Visit(path)
+		autoTrack(state, ev.Project, path)
+	}
+
+	cfg := state.cfg
+	return tea.ExecProcess(exec.Command(tool, path), func(err error) tea.Msg {
+		if err != nil {
+			return toastMsg{text: fmt.Sprintf("Failed to open with %s: %v", tool, err), isError: true}
+		}
+		if err := hooks.Post(cfg, ev); err != nil {
+			return toastMsg{text: err.Error(), isError: true}
+		}
+		return configChangedMsg{}
+	})
+}
+
+// autoTrack starts a time tracking session on a project being opened, when
+// the config asks for it.
+func autoTrack(state *appState, projectName, path string) {
+	if state.cfg.AutoTrack {
+		timelog.Start(projectName, path, true)
+	}
+}
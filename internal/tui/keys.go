@@ -0,0 +1,252 @@
+package tui
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/key"
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// Actions that keys are bound to. The names are the ones used under
+// "keys.bindings" in the config.
+const (
+	keyUp        = "up"
+	keyDown      = "down"
+	keyPageUp    = "page_up"
+	keyPageDown  = "page_down"
+	keyTop       = "top"
+	keyBottom    = "bottom"
+	keySelect    = "select"
+	keyBack      = "back"
+	keyQuit      = "quit"
+	keyHelp      = "help"
+	keyFilter    = "filter"
+	keySort      = "sort"
+	keyReverse   = "reverse_sort"
+	keyCreate    = "create"
+	keyAdd       = "add"
+	keyDelete    = "delete"
+	keyStatus    = "status"
+	keyMark      = "mark"
+	keyUndo      = "undo"
+	keyGoto      = "goto"
+	keyTasks     = "tasks"
+	keyMenu      = "menu"
+	keyDash      = "dashboard"
+	keyReadme    = "readme"
+	keyBoard     = "board"
+	keyLeft      = "left"
+	keyRight     = "right"
+	keyMoveLeft  = "move_left"
+	keyMoveRight = "move_right"
+	keyWorkspace = "filter_workspace"
+	keyTag       = "filter_tag"
+	keyEdit      = "edit"
+	keyLink      = "open_link"
+	keyTrack     = "track"
+	keyNote      = "note"
+)
+
+type keyAction struct {
+	name string
+	help string
+	keys []string
+}
+
+// keyActions lists every action with its default keys, in the order the help
+// overlay shows them.
+var keyActions = []keyAction{
+	{keyUp, "Move up", []string{"up", "k"}},
+	{keyDown, "Move down", []string{"down", "j"}},
+	{keyPageUp, "Page up", []string{"pgup", "ctrl+b"}},
+	{keyPageDown, "Page down", []string{"pgdown", "ctrl+f"}},
+	{keyTop, "Go to the top", []string{"home"}},
+	{keyBottom, "Go to the bottom", []string{"end"}},
+	{keySelect, "Open the selected item", []string{"enter"}},
+	{keyBack, "Go back", []string{"r", "esc"}},
+	{keyQuit, "Quit", []string{"q", "ctrl+c"}},
+	{keyHelp, "Show keys", []string{"?"}},
+	{keyFilter, "Filter", []string{"/"}},
+	{keySort, "Next sort column", []string{"o"}},
+	{keyReverse, "Reverse sort", []string{"O"}},
+	{keyCreate, "Create workspace", []string{"c"}},
+	{keyAdd, "Add project", []string{"a"}},
+	{keyDelete, "Delete", []string{"d"}},
+	{keyStatus, "Change status", []string{"s"}},
+	{keyMark, "Select for bulk status", []string{" "}},
+	{keyUndo, "Undo last delete", []string{"u"}},
+	{keyGoto, "Open a shell there", []string{"g"}},
+	{keyTasks, "Run a task", []string{"t"}},
+	{keyMenu, "Open with…", []string{"m"}},
+	{keyDash, "Dashboard", []string{"h"}},
+	{keyReadme, "View README in glow", []string{"v"}},
+	{keyBoard, "Status board", []string{"b"}},
+	{keyLeft, "Previous column", []string{"left"}},
+	{keyRight, "Next column", []string{"right"}},
+	{keyMoveLeft, "Move card left", []string{"h"}},
+	{keyMoveRight, "Move card right", []string{"l"}},
+	{keyWorkspace, "Filter by workspace", []string{"w"}},
+	{keyTag, "Filter by tag", []string{"#"}},
+	{keyEdit, "Edit description, tags and links", []string{"e"}},
+	{keyLink, "Open a project link", []string{"L"}},
+	{keyTrack, "Start or stop tracking time", []string{"T"}},
+	{keyNote, "Add a journal note", []string{"n"}},
+}
+
+// keyPresets replace the defaults of the actions they list.
+var keyPresets = map[string]map[string][]string{
+	"default": {},
+	"vim": {
+		keyPageUp:   {"ctrl+u", "ctrl+b", "pgup"},
+		keyPageDown: {"ctrl+d", "ctrl+f", "pgdown"},
+		keyTop:      {"home"},
+		keyBottom:   {"G", "end"},
+		keySelect:   {"enter", "l"},
+		keyBack:     {"h", "esc"},
+		keyDash:     {"D"},
+	},
+	"emacs": {
+		keyUp:       {"ctrl+p", "up"},
+		keyDown:     {"ctrl+n", "down"},
+		keyPageUp:   {"alt+v", "pgup"},
+		keyPageDown: {"ctrl+v", "pgdown"},
+		keyTop:      {"alt+<", "home"},
+		keyBottom:   {"alt+>", "end"},
+		keyBack:     {"ctrl+g", "esc"},
+		keyFilter:   {"ctrl+s", "/"},
+		keyLeft:     {"ctrl+b", "left"},
+		keyRight:    {"ctrl+f", "right"},
+	},
+}
+
+// keyMap holds the bindings in effect, by action.
+type keyMap map[string]key.Binding
+
+// loadKeyMap builds the keymap from the config: the defaults, then the
+// preset, then the user's own bindings. Problems are reported but never stop
+// the TUI; the offending entries are ignored.
+func loadKeyMap(cfg config.KeyConfig) (keyMap, error) {
+	var errs []error
+
+	preset, ok := keyPresets[cfg.Preset]
+	if cfg.Preset != "" && !ok {
+		errs = append(errs, fmt.Errorf("unknown key preset '%s'", cfg.Preset))
+	}
+
+	known := make(map[string]bool, len(keyActions))
+	for _, a := range keyActions {
+		known[a.name] = true
+	}
+	var unknown []string
+	for name := range cfg.Bindings {
+		if !known[name] {
+			unknown = append(unknown, name)
+		}
+	}
+	sort.Strings(unknown)
+	for _, name := range unknown {
+		errs = append(errs, fmt.Errorf("unknown key action '%s'", name))
+	}
+
+	keys := make(keyMap, len(keyActions))
+	for _, a := range keyActions {
+		bound := a.keys
+		if k, ok := preset[a.name]; ok {
+			bound = k
+		}
+		if k, ok := cfg.Bindings[a.name]; ok {
+			bound = normalizeKeys(k)
+		}
+		keys[a.name] = key.NewBinding(key.WithKeys(bound...), key.WithHelp(keyNames(bound), a.help))
+	}
+
+	return keys, errors.Join(errs...)
+}
+
+// normalizeKeys accepts "space" for the space bar, as bubbletea reports it
+// as " ".
+func normalizeKeys(keys []string) []string {
+	out := make([]string, len(keys))
+	for i, k := range keys {
+		if k == "space" {
+			k = " "
+		}
+		out[i] = k
+	}
+	return out
+}
+
+func (k keyMap) matches(msg tea.KeyMsg, action string) bool {
+	return key.Matches(msg, k[action])
+}
+
+// label is the first key of an action, for buttons such as "d Delete".
+func (k keyMap) label(action string) string {
+	keys := k[action].Keys()
+	if len(keys) == 0 {
+		return ""
+	}
+	return keyName(keys[0])
+}
+
+// hint renders "↑/↓: Navigate" from the first key of each action.
+func (k keyMap) hint(text string, actions ...string) string {
+	labels := make([]string, len(actions))
+	for i, action := range actions {
+		labels[i] = k.label(action)
+	}
+	return strings.Join(labels, "/") + ": " + text
+}
+
+// viewport returns a viewport keymap following the navigation bindings.
+func (k keyMap) viewport() viewport.KeyMap {
+	km := viewport.DefaultKeyMap()
+	km.Up = k[keyUp]
+	km.Down = k[keyDown]
+	km.PageUp = k[keyPageUp]
+	km.PageDown = k[keyPageDown]
+	km.HalfPageUp.SetEnabled(false)
+	km.HalfPageDown.SetEnabled(false)
+	return km
+}
+
+func keyNames(keys []string) string {
+	names := make([]string, len(keys))
+	for i, k := range keys {
+		names[i] = keyName(k)
+	}
+	return strings.Join(names, "/")
+}
+
+func keyName(k string) string {
+	switch k {
+	case " ":
+		return "Space"
+	case "up":
+		return "↑"
+	case "down":
+		return "↓"
+	case "left":
+		return "←"
+	case "right":
+		return "→"
+	case "pgup":
+		return "PgUp"
+	case "pgdown":
+		return "PgDn"
+	case "home":
+		return "Home"
+	case "end":
+		return "End"
+	case "enter":
+		return "Enter"
+	case "esc":
+		return "Esc"
+	}
+	return k
+}
@@ -0,0 +1,223 @@
+package tui
+
+import (
+	"fmt"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/charmbracelet/lipgloss/table"
+	"github.com/henrynguci/orbit/internal/theme"
+)
+
+// defaultWidth is used until the first tea.WindowSizeMsg arrives.
+const defaultWidth = 100
+
+// column describes a table column. Widths count content only; fitColumns
+// adds the cell padding.
+type column struct {
+	title string
+	width int
+	min   int
+	// drop is the order in which columns disappear on narrow terminals:
+	// the highest value goes first and 0 is never dropped.
+	drop int
+	// flex columns take whatever width is left over.
+	flex bool
+}
+
+// fitColumns returns the rendered width of each column for a terminal that
+// is total cells wide. Dropped columns get a width of 0.
+func fitColumns(columns []column, total int) []int {
+	total = screenWidth(total)
+
+	kept := make([]bool, len(columns))
+	for i := range kept {
+		kept[i] = true
+	}
+
+	needed := func(width func(column) int) int {
+		sum := 1
+		for i, c := range columns {
+			if kept[i] {
+				sum += width(c) + 2 + 1
+			}
+		}
+		return sum
+	}
+
+	for needed(func(c column) int { return c.min }) > total {
+		drop := -1
+		for i, c := range columns {
+			if kept[i] && c.drop > 0 && (drop < 0 || c.drop > columns[drop].drop) {
+				drop = i
+			}
+		}
+		if drop < 0 {
+			break
+		}
+		kept[drop] = false
+	}
+
+	widths := make([]int, len(columns))
+	for i, c := range columns {
+		if kept[i] {
+			widths[i] = c.width + 2
+		}
+	}
+
+	// Shrink the widest column that still can until the table fits.
+	for over := needed(func(c column) int { return c.width }) - total; over > 0; over-- {
+		widest := -1
+		for i, c := range columns {
+			if kept[i] && widths[i] > c.min+2 && (widest < 0 || widths[i] > widths[widest]) {
+				widest = i
+			}
+		}
+		if widest < 0 {
+			break
+		}
+		widths[widest]--
+	}
+
+	// Hand the rest of the line to the flexible columns.
+	used := 1
+	flexible := 0
+	for i, c := range columns {
+		if kept[i] {
+			used += widths[i] + 1
+			if c.flex {
+				flexible++
+			}
+		}
+	}
+	if extra := total - used; extra > 0 && flexible > 0 {
+		for i, c := range columns {
+			if kept[i] && c.flex {
+				widths[i] += extra / flexible
+			}
+		}
+	}
+
+	return widths
+}
+
+func screenWidth(width int) int {
+	if width <= 0 {
+		return defaultWidth
+	}
+	return width
+}
+
+func renderTitle(text string, width int) string {
+	return titleStyle.Width(screenWidth(width)).Render(text)
+}
+
+// cellText fits text into a column of the given rendered width.
+func cellText(text string, width int) string {
+	return truncateString(text, max(width-2, 4))
+}
+
+// renderTable draws rows with the widths from fitColumns, leaving out the
+// dropped columns. cursor is relative to rows; rowStyle colours the other
+// rows and may be nil.
+func renderTable(columns []column, widths []int, rows [][]string, cursor int, rowStyle func(row int) lipgloss.Style) string {
+	var keep []int
+	var headers []string
+	for i, c := range columns {
+		if widths[i] > 0 {
+			keep = append(keep, i)
+			headers = append(headers, c.title)
+		}
+	}
+
+	shown := make([][]string, len(rows))
+	for r, row := range rows {
+		for _, i := range keep {
+			shown[r] = append(shown[r], row[i])
+		}
+	}
+
+	return table.New().
+		Border(theme.Border()).
+		BorderStyle(lipgloss.NewStyle().Foreground(primaryColor)).
+		Headers(headers...).
+		Rows(shown...).
+		StyleFunc(func(row, col int) lipgloss.Style {
+			style := lipgloss.NewStyle().Width(widths[keep[col]]).Padding(0, 1)
+
+			if row == table.HeaderRow {
+				return style.
+					Bold(true).
+					Foreground(primaryColor).
+					BorderForeground(primaryColor).
+					Align(lipgloss.Left)
+			}
+
+			if row == cursor {
+				return selectedStyle.Inherit(style)
+			}
+
+			if rowStyle != nil {
+				return rowStyle(row).Inherit(style)
+			}
+			return style.Foreground(textColor)
+		}).
+		Render()
+}
+
+// tableChrome is the number of lines a table uses besides its rows: the top
+// border, the header, the header separator and the bottom border.
+const tableChrome = 4
+
+// pageSize returns how many table rows fit on screen for a view laid out as
+// header, table, a blank line and footer. A line is kept free for toasts.
+func pageSize(height int, header, footer string) int {
+	if height <= 0 {
+		return 1 << 20
+	}
+	newlines := strings.Count(header, "\n") + strings.Count(footer, "\n")
+	return max(height-newlines-tableChrome-3, 1)
+}
+
+// navigate applies the cursor keys shared by every table and reports whether
+// msg was one of them.
+func navigate(keys keyMap, msg tea.KeyMsg, cursor, count, page int) (int, bool) {
+	switch {
+	case keys.matches(msg, keyUp):
+		cursor--
+	case keys.matches(msg, keyDown):
+		cursor++
+	case keys.matches(msg, keyPageUp):
+		cursor -= page
+	case keys.matches(msg, keyPageDown):
+		cursor += page
+	case keys.matches(msg, keyTop):
+		cursor = 0
+	case keys.matches(msg, keyBottom):
+		cursor = count - 1
+	default:
+		return cursor, false
+	}
+	return clampCursor(cursor, count), true
+}
+
+// scrollOffset returns the first row to show so that the cursor stays on a
+// page, moving as little as possible from the previous offset.
+func scrollOffset(offset, cursor, count, page int) int {
+	if cursor < offset {
+		offset = cursor
+	}
+	if cursor >= offset+page {
+		offset = cursor - page + 1
+	}
+	return max(min(offset, count-page), 0)
+}
+
+// scrollInfo describes the rows on screen when not all of them fit.
+func scrollInfo(offset, page, count int) string {
+	if count <= page {
+		return ""
+	}
+	return fmt.Sprintf("  ·  rows %d–%d of %d", offset+1, min(offset+page, count), count)
+}
@@ -0,0 +1,42 @@
+package tui
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/plugins"
+)
+
+func runPluginMenuEntry(cfg *config.Config, path string, ref string) tea.Cmd {
+	name, indexText, _ := strings.Cut(ref, ":")
+	index, err := strconv.Atoi(indexText)
+	if err != nil {
+		return nil
+	}
+
+	plugin, ok := plugins.Find(name)
+	if !ok || index < 0 || index >= len(plugin.Manifest.Menu) {
+		return nil
+	}
+	entry := plugin.Manifest.Menu[index]
+
+	var project *config.Project
+	for _, p := range config.GetAllProjects(cfg) {
+		if p.Path == path {
+			project = &p
+			break
+		}
+	}
+
+	cmd := plugin.Command(entry.Args, project, cfg)
+	cmd.Dir = path
+
+	screen, err := newOutputScreen(entry.Label, strings.Join(append([]string{plugins.Prefix + plugin.Name}, entry.Args...), " "), cmd)
+	if err != nil {
+		return pushScreen(newErrorDialog(entry.Label, fmt.Sprintf("Plugin '%s' failed: %v", plugin.Name, err)))
+	}
+	return pushScreen(screen)
+}
@@ -1,23 +1,80 @@
 package tui
 
 import (
+	"path/filepath"
 	"strings"
 
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
 	"github.com/henrynguci/orbit/internal/config"
 )
 
 type lipglossProjectModel struct {
+	state     *appState
 	projects  []config.Project
+	visible   []filteredRow
+	filter    tableFilter
+	sort      tableSort
 	workspace string
 	cursor    int
+	offset    int
 	selected  string
-	action    string
-	quitting  bool
 	menuOpen  bool
 	menuIndex int
+	menuItems []menuItem
+	marked    map[string]bool
+}
+
+func newProjectScreen(state *appState, workspace string) lipglossProjectModel {
+	m := lipglossProjectModel{
+		state:     state,
+		filter:    newTableFilter(),
+		sort:      loadTableSort(state, "projects", []string{sortFrecency, sortName, sortStatus, sortModified, sortActivity}),
+		workspace: workspace,
+		marked:    make(map[string]bool),
+		menuItems: actionMenuItems(),
+	}
+	m.setProjects(getProjectsInWorkspace(state.cfg, workspace))
+	return m
+}
+
+// setProjects sorts and filters projects, keeping the cursor on the same
+// project if it is still shown.
+func (m *lipglossProjectModel) setProjects(projects []config.Project) {
+	current, hadCurrent := m.current()
+
+	m.projects = sortItems(m.sort, projects, func(p config.Project) sortFields {
+		return m.sort.fields(p.Name, "", p.Status, p.Path)
+	})
+	m.applyFilter()
+
+	if hadCurrent {
+		m.selectProject(current.Path)
+	}
+}
+
+// selectProject moves the cursor to the project at path if it is shown.
+func (m *lipglossProjectModel) selectProject(path string) {
+	if i, ok := findRow(m.visible, func(index int) bool { return m.projects[index].Path == path }); ok {
+		m.cursor = i
+		m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+	}
+}
+
+func (m *lipglossProjectModel) applyFilter() {
+	m.visible = m.filter.apply(len(m.projects), func(i int) []string {
+		return projectFields(m.projects[i], filepath.Base(m.workspace))
+	})
+	m.cursor = clampCursor(m.cursor, len(m.visible))
+	m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+}
+
+// current returns the project under the cursor, looked up through the filter.
+func (m lipglossProjectModel) current() (config.Project, bool) {
+	if len(m.visible) == 0 {
+		return config.Project{}, false
+	}
+	return m.projects[m.visible[m.cursor].index], true
 }
 
 func (m lipglossProjectModel) Init() tea.Cmd {
@@ -25,240 +82,221 @@ func (m lipglossProjectModel) Init() tea.Cmd {
 }
 
 func (m lipglossProjectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
+	switch msg.(type) {
+	case tea.WindowSizeMsg:
+		m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+		return m, nil
+	case configChangedMsg:
+		m.setProjects(getProjectsInWorkspace(m.state.cfg, m.workspace))
+		return m, nil
+	}
 
 	if m.menuOpen {
-		switch msg := msg.(type) {
-		case tea.KeyMsg:
-			switch msg.String() {
-			case "up", "k":
-				if m.menuIndex > 0 {
-					m.menuIndex--
-				}
-			case "down", "j":
-				if m.menuIndex < 2 {
-					m.menuIndex++
-				}
-			case "enter":
-				m.menuOpen = false
-				actions := []string{"code", "cursor", "antigravityy"}
-				m.action = "code_" + actions[m.menuIndex]
-				return m, tea.Quit
-			case "esc", "q":
-				m.menuOpen = false
-				return m, nil
-			}
+		if msg, ok := msg.(tea.KeyMsg); ok {
+			var cmd tea.Cmd
+			m.menuOpen, m.menuIndex, cmd = updateMenu(m.state, msg, m.menuItems, m.menuIndex, m.selected)
+			return m, cmd
 		}
 		return m, nil
 	}
 
 	switch msg := msg.(type) {
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			m.quitting = true
-			m.action = "quit"
-			return m, tea.Quit
-		case "a":
-			m.action = "add"
+		keys := m.state.keys
+
+		var handled bool
+		var cmd tea.Cmd
+		if m.filter, handled, cmd = m.filter.update(keys, msg); handled {
+			m.applyFilter()
+			return m, cmd
+		}
+
+		if cursor, ok := navigate(keys, msg, m.cursor, len(m.visible), m.pageSize()); ok {
+			m.cursor = cursor
+			m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+			return m, nil
+		}
+
+		p, ok := m.current()
+		switch {
+		case keys.matches(msg, keyQuit):
 			return m, tea.Quit
-		case "d":
-			if len(m.projects) > 0 {
-				m.selected = m.projects[m.cursor].Name
-				m.action = "delete"
-				return m, tea.Quit
+		case keys.matches(msg, keyHelp):
+			return m, pushScreen(newHelpScreen(m.state, "Projects", navigationHelp, projectHelp(keyAdd)))
+		case keys.matches(msg, keyAdd):
+			return m, pushScreen(handleAddProjectToWorkspace(m.state, m.workspace))
+		case keys.matches(msg, keyDelete):
+			if ok {
+				return m, pushScreen(handleDeleteProject(m.state, p.Name))
 			}
-		case "s":
-			if len(m.projects) > 0 {
-				m.selected = m.projects[m.cursor].Name
-				m.action = "status"
-				return m, tea.Quit
+		case keys.matches(msg, keyStatus):
+			if len(m.marked) > 0 {
+				names := markedNames(m.marked)
+				m.marked = make(map[string]bool)
+				return m, pushScreen(handleBulkChangeStatus(m.state, names))
 			}
-		case "g":
-			if len(m.projects) > 0 {
-				m.selected = m.projects[m.cursor].Path
-				m.action = "goto"
-				return m, tea.Quit
+			if ok {
+				return m, pushScreen(handleChangeStatus(m.state, p.Name))
 			}
-		case "m":
-			if len(m.projects) > 0 {
-				m.selected = m.projects[m.cursor].Path
-				m.menuOpen = true
-				m.menuIndex = 0
+		case keys.matches(msg, keyEdit):
+			if ok {
+				return m, pushScreen(handleEditProject(m.state, p.Name))
 			}
-		case "r", "esc":
-			m.action = "return"
-			return m, tea.Quit
-		case "enter":
-			if len(m.projects) > 0 {
-				m.selected = m.projects[m.cursor].Name
-				m.action = "view"
-				return m, tea.Quit
+		case keys.matches(msg, keyLink):
+			if ok {
+				return m, openProjectLink(m.state, p.Name)
+			}
+		case keys.matches(msg, keyNote):
+			if ok {
+				return m, pushScreen(handleAddNote(m.state, p.Name))
 			}
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
+		case keys.matches(msg, keyTrack):
+			if ok {
+				return m, toggleTracking(m.state, p.Name, p.Path)
 			}
-		case "down", "j":
-			if m.cursor < len(m.projects)-1 {
-				m.cursor++
+		case keys.matches(msg, keyMark):
+			if ok {
+				toggleMarked(m.marked, p.Name)
 			}
+		case keys.matches(msg, keyUndo):
+			return m, handleUndoDelete()
+		case keys.matches(msg, keyGoto):
+			if ok {
+				return m, gotoDirectory(m.state, p.Path)
+			}
+		case keys.matches(msg, keyTasks):
+			if ok {
+				return m, pushScreen(handleRunTask(p.Path))
+			}
+		case keys.matches(msg, keyMenu):
+			if ok {
+				m.selected = p.Path
+				m.menuOpen = true
+				m.menuIndex = 0
+			}
+		case keys.matches(msg, keyBack):
+			return m, popScreen
+		case keys.matches(msg, keySelect):
+			if ok {
+				return m, showProjectView(m.state, p)
+			}
+		case keys.matches(msg, keySort):
+			m.sort = m.sort.next()
+			m.sort.save()
+			m.setProjects(m.projects)
+		case keys.matches(msg, keyReverse):
+			m.sort = m.sort.reversed()
+			m.sort.save()
+			m.setProjects(m.projects)
 		}
 	}
-	return m, cmd
+	return m, nil
 }
 
-func (m lipglossProjectModel) View() string {
-	if m.quitting {
-		return ""
+func (m lipglossProjectModel) columns() []column {
+	return []column{
+		{title: "Project", width: 18, min: 8},
+		{title: "Status", width: 10, min: 8},
+		{title: "Tags", width: 12, min: 6, drop: 4},
+		{title: "Description", width: 28, min: 10, drop: 5, flex: true},
+		{title: "Week", width: 9, min: 9, drop: 3},
+		{title: "Last Modified", width: 18, min: 16, drop: 1},
+		{title: "Path", width: 30, min: 12, drop: 2, flex: true},
 	}
+}
 
+// header renders everything above the table. info is the scroll position,
+// which does not change how many lines the header takes.
+func (m lipglossProjectModel) header(info string) string {
 	var s strings.Builder
 	s.WriteString("\n")
-	s.WriteString(titleStyle.Render("Workspace: "+m.workspace) + "\n")
-	s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Italic(true).Render(m.workspace) + "\n\n")
+	s.WriteString(renderTitle("Workspace: "+filepath.Base(m.workspace), m.state.width) + "\n")
+	s.WriteString(subtitleStyle.Render(m.workspace) + "\n\n")
 
+	s.WriteString(m.filter.view(len(m.visible), len(m.projects), "projects"))
 	if len(m.projects) > 0 {
-		s.WriteString(m.renderTable() + "\n\n")
-	} else {
-		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Render("  No projects found. Press 'a' to add one.") + "\n\n")
+		s.WriteString(m.sort.view(info))
 	}
+	return s.String()
+}
+
+func (m lipglossProjectModel) footer() string {
+	var s strings.Builder
 
+	keys := m.state.keys
 	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
-		greenBtn.Render("a Add"),
-		redBtn.Render("d Delete"),
-		blueBtn.Render("s Status"),
-		blueBtn.Render("g Goto"),
-		greenBtn.Render("m Code"),
-		yellowBtn.Render("r Return"),
-		purpleBtn.Render("q Quit"),
+		greenBtn.Render(keys.label(keyAdd)+" Add"),
+		redBtn.Render(keys.label(keyDelete)+" Delete"),
+		blueBtn.Render(keys.label(keyStatus)+" Status"),
+		blueBtn.Render(keys.label(keyEdit)+" Edit"),
+		blueBtn.Render(keys.label(keyNote)+" Note"),
+		blueBtn.Render(keys.label(keyGoto)+" Goto"),
+		yellowBtn.Render(keys.label(keyUndo)+" Undo"),
+		greenBtn.Render(keys.label(keyMenu)+" Code"),
+		greenBtn.Render(keys.label(keyTasks)+" Tasks"),
+		yellowBtn.Render(keys.label(keyBack)+" Return"),
+		purpleBtn.Render(keys.label(keyQuit)+" Quit"),
 	)
-	s.WriteString(helpBar + "\n")
+	s.WriteString(lipgloss.NewStyle().MaxWidth(screenWidth(m.state.width)).Render(helpBar) + "\n")
 
 	if m.menuOpen {
-		menuItems := []string{"open with code", "open with cursor", "open with antigravityy"}
-		var menuLines []string
-		for i, item := range menuItems {
-			line := "  " + item
-			if i == m.menuIndex {
-				line = " > " + lipgloss.NewStyle().Foreground(primaryColor).Bold(true).Render(item)
-			}
-			menuLines = append(menuLines, line)
-		}
-
-		menuBox := lipgloss.NewStyle().
-			Border(lipgloss.RoundedBorder()).
-			BorderForeground(primaryColor).
-			Padding(1, 2).
-			Render(lipgloss.JoinVertical(lipgloss.Left, menuLines...))
-
-		s.WriteString("\n" + lipgloss.NewStyle().MarginLeft(4).Render(menuBox) + "\n")
+		s.WriteString("\n" + renderMenu(m.menuItems, m.menuIndex) + "\n")
 	}
 
 	if len(m.projects) > 0 && !m.menuOpen {
-		s.WriteString(lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Render("\n  ↑/↓: Navigate  Enter: View README") + "\n")
+		s.WriteString(hintStyle.MaxWidth(screenWidth(m.state.width)).Render("\n  "+tableHints(keys)) + "\n")
+		if len(m.marked) > 0 {
+			s.WriteString(lipgloss.NewStyle().Foreground(secondaryColor).Render(markedSummary(keys, len(m.marked))) + "\n")
+		}
 	}
 
 	return s.String()
 }
 
-func (m lipglossProjectModel) renderTable() string {
-
-	const (
-		projectWidth = 15
-		statusWidth  = 15
-		lastModWidth = 20
-		pathWidth    = 45
-	)
-
-
-	var rows [][]string
-	for _, p := range m.projects {
-		status := p.Status
-		if status == "" {
-			status = "active"
-		}
-
-		var statusText string
-		switch status {
-		case "active":
-			statusText = lipgloss.NewStyle().Foreground(successColor).Render(status)
-		case "archived":
-			statusText = lipgloss.NewStyle().Foreground(warningColor).Render(status)
-		case "done":
-			statusText = lipgloss.NewStyle().Foreground(secondaryColor).Render(status)
-		default:
-			statusText = status
-		}
-
-		lastMod := getLastModifiedTime(p.Path)
+func (m lipglossProjectModel) pageSize() int {
+	return pageSize(m.state.height, m.header(""), m.footer())
+}
 
-		project := truncateString(p.Name, projectWidth)
-		path := truncateString(p.Path, pathWidth)
+func (m lipglossProjectModel) View() string {
+	page := m.pageSize()
+	s := m.header(scrollInfo(scrollOffset(m.offset, m.cursor, len(m.visible), page), page, len(m.visible)))
 
-		rows = append(rows, []string{
-			project,
-			statusText,
-			lastMod,
-			path,
-		})
+	if len(m.visible) > 0 {
+		s += m.renderTable() + "\n\n"
+	} else if len(m.projects) > 0 {
+		s += hintStyle.Render("  No projects match the filter.") + "\n\n"
+	} else {
+		s += hintStyle.Render("  No projects found. Press 'a' to add one.") + "\n\n"
 	}
 
+	return s + m.footer()
+}
 
-	t := table.New().
-		Border(lipgloss.RoundedBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(primaryColor)).
-		Headers("Project", "Status", "Last Modified", "Path").
-		Rows(rows...).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			var width int
-			switch col {
-			case 0:
-				width = projectWidth
-			case 1:
-				width = statusWidth
-			case 2:
-				width = lastModWidth
-			case 3:
-				width = pathWidth
-			}
-
-			style := lipgloss.NewStyle().Width(width).Padding(0, 1)
-
-
-			if row == table.HeaderRow {
-				return style.
-					Bold(true).
-					Foreground(primaryColor).
-					BorderForeground(primaryColor).
-					Align(lipgloss.Left)
-			}
+func (m lipglossProjectModel) renderTable() string {
+	columns := m.columns()
+	widths := fitColumns(columns, m.state.width)
 
+	page := m.pageSize()
+	offset := scrollOffset(m.offset, m.cursor, len(m.visible), page)
+	end := min(offset+page, len(m.visible))
 
-			if row == m.cursor {
-				return style.
-					Background(primaryColor).
-					Foreground(whiteColor).
-					Bold(true)
-			}
+	var rows [][]string
+	for _, v := range m.visible[offset:end] {
+		p := m.projects[v.index]
+		statusText := renderStatus(m.state.cfg, config.StatusOf(p))
 
+		prefix := markedPrefix(m.marked, p.Name)
 
-			return style.Foreground(whiteColor)
+		rows = append(rows, []string{
+			highlightMatches(prefix+p.Name, cellMatch(v, fieldName), len([]rune(prefix)), max(widths[0]-2, 4)),
+			statusText,
+			highlightMatches(strings.Join(p.Tags, " "), cellMatch(v, fieldTags), 0, max(widths[2]-2, 4)),
+			highlightMatches(p.Description, cellMatch(v, fieldDescription), 0, max(widths[3]-2, 4)),
+			cellText(formatWeekTime(m.state.weekTime(p.Path)), widths[4]),
+			cellText(getLastModifiedTime(p.Path), widths[5]),
+			cellText(p.Path, widths[6]),
 		})
-
-	return t.Render()
-}
-
-func selectProjectLipgloss(projects []config.Project, workspace string) (string, string) {
-	m := lipglossProjectModel{
-		projects:  projects,
-		workspace: workspace,
-		cursor:    0,
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	finalModel, _ := p.Run()
-	result := finalModel.(lipglossProjectModel)
-
-	return result.selected, result.action
+	return renderTable(columns, widths, rows, m.cursor-offset, nil)
 }
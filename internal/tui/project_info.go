@@ -0,0 +1,298 @@
+package tui
+
+import (
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/henrynguci/orbit/internal/journal"
+)
+
+const (
+	recentCommitCount = 5
+	journalCount      = 3
+	languageCount     = 5
+	// maxLanguageFiles bounds the walk for the language breakdown so that
+	// opening a huge checkout stays quick.
+	maxLanguageFiles = 20000
+	maxReadmeBytes   = 256 * 1024
+)
+
+// projectInfo is everything the detail screen shows that has to be read from
+// disk. It is gathered off the UI goroutine.
+type projectInfo struct {
+	hasRepo bool
+	branch  string
+	remote  string
+	changed int
+	commits []commitSummary
+
+	docsSize   int64
+	secretSize int64
+
+	languages []languageShare
+
+	// journal holds the latest notes, newest first.
+	journal []journal.Entry
+
+	readmePath string
+	readme     string
+}
+
+type commitSummary struct {
+	hash    string
+	subject string
+	author  string
+	when    time.Time
+}
+
+type languageShare struct {
+	name    string
+	bytes   int64
+	percent float64
+}
+
+// languageExtensions maps file extensions to the language they count towards.
+// Prose and data formats are left out so they do not drown the code.
+var languageExtensions = map[string]string{
+	".go":     "Go",
+	".rs":     "Rust",
+	".py":     "Python",
+	".js":     "JavaScript",
+	".jsx":    "JavaScript",
+	".mjs":    "JavaScript",
+	".cjs":    "JavaScript",
+	".ts":     "TypeScript",
+	".tsx":    "TypeScript",
+	".java":   "Java",
+	".kt":     "Kotlin",
+	".swift":  "Swift",
+	".c":      "C",
+	".h":      "C",
+	".cc":     "C++",
+	".cpp":    "C++",
+	".hpp":    "C++",
+	".cs":     "C#",
+	".rb":     "Ruby",
+	".php":    "PHP",
+	".sh":     "Shell",
+	".bash":   "Shell",
+	".zsh":    "Shell",
+	".lua":    "Lua",
+	".dart":   "Dart",
+	".scala":  "Scala",
+	".ex":     "Elixir",
+	".exs":    "Elixir",
+	".hs":     "Haskell",
+	".ml":     "OCaml",
+	".zig":    "Zig",
+	".nix":    "Nix",
+	".sql":    "SQL",
+	".html":   "HTML",
+	".css":    "CSS",
+	".scss":   "SCSS",
+	".vue":    "Vue",
+	".svelte": "Svelte",
+}
+
+// skippedDirs are never walked for the language breakdown.
+var skippedDirs = map[string]bool{
+	"node_modules": true,
+	"vendor":       true,
+	"dist":         true,
+	"build":        true,
+	"target":       true,
+}
+
+func loadProjectInfo(projectPath string) projectInfo {
+	var info projectInfo
+
+	loadGitInfo(&info, projectPath)
+
+	info.docsSize = dirSize(filepath.Join(projectPath, "docs"))
+	info.secretSize = dirSize(filepath.Join(projectPath, "secret"))
+
+	entries, _ := journal.Read(projectPath)
+	for i := len(entries) - 1; i >= max(len(entries)-journalCount, 0); i-- {
+		info.journal = append(info.journal, entries[i])
+	}
+
+	// Without a repo/ the code sits next to docs/ and secret/, which are
+	// not part of it.
+	source, skip := filepath.Join(projectPath, "repo"), []string(nil)
+	if _, err := os.Stat(source); err != nil {
+		source, skip = projectPath, []string{"docs", "secret"}
+	}
+	info.languages = languageBreakdown(source, skip)
+
+	if readmePath := findReadme(projectPath); readmePath != "" {
+		info.readmePath = readmePath
+		info.readme = readPrefix(readmePath, maxReadmeBytes)
+	}
+
+	return info
+}
+
+func loadGitInfo(info *projectInfo, projectPath string) {
+	repo, ok := openProjectRepo(projectPath)
+	if !ok {
+		return
+	}
+	info.hasRepo = true
+
+	if remote, err := repo.Remote("origin"); err == nil && len(remote.Config().URLs) > 0 {
+		info.remote = remote.Config().URLs[0]
+	}
+
+	head, err := repo.Head()
+	if err != nil {
+		return
+	}
+	if head.Name().IsBranch() {
+		info.branch = head.Name().Short()
+	} else {
+		info.branch = "detached at " + head.Hash().String()[:7]
+	}
+
+	if worktree, err := repo.Worktree(); err == nil {
+		if status, err := worktree.Status(); err == nil {
+			for _, file := range status {
+				if file.Worktree != git.Unmodified || file.Staging != git.Unmodified {
+					info.changed++
+				}
+			}
+		}
+	}
+
+	commits, err := repo.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return
+	}
+	defer commits.Close()
+
+	for len(info.commits) < recentCommitCount {
+		commit, err := commits.Next()
+		if err != nil {
+			break
+		}
+		subject, _, _ := strings.Cut(strings.TrimSpace(commit.Message), "\n")
+		info.commits = append(info.commits, commitSummary{
+			hash:    commit.Hash.String()[:7],
+			subject: subject,
+			author:  commit.Author.Name,
+			when:    commit.Author.When,
+		})
+	}
+}
+
+// dirSize adds up the sizes of the files under path. Unreadable entries are
+// skipped rather than failing the whole screen.
+func dirSize(path string) int64 {
+	var size int64
+	filepath.WalkDir(path, func(_ string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			size += info.Size()
+		}
+		return nil
+	})
+	return size
+}
+
+// languageBreakdown returns the languages under root by bytes of source,
+// largest first. Hidden and dependency directories are skipped, as are the
+// top-level directories named in skip.
+func languageBreakdown(root string, skip []string) []languageShare {
+	totals := make(map[string]int64)
+	var total int64
+	files := 0
+
+	filepath.WalkDir(root, func(path string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return nil
+		}
+		if d.IsDir() {
+			name := d.Name()
+			if path != root && (strings.HasPrefix(name, ".") || skippedDirs[name]) {
+				return filepath.SkipDir
+			}
+			if filepath.Dir(path) == root && slices.Contains(skip, name) {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		files++
+		if files > maxLanguageFiles {
+			return filepath.SkipAll
+		}
+
+		language, ok := languageExtensions[strings.ToLower(filepath.Ext(d.Name()))]
+		if !ok {
+			return nil
+		}
+		if info, err := d.Info(); err == nil {
+			totals[language] += info.Size()
+			total += info.Size()
+		}
+		return nil
+	})
+
+	if total == 0 {
+		return nil
+	}
+
+	shares := make([]languageShare, 0, len(totals))
+	for name, bytes := range totals {
+		shares = append(shares, languageShare{name: name, bytes: bytes, percent: float64(bytes) * 100 / float64(total)})
+	}
+	sort.Slice(shares, func(i, j int) bool {
+		if shares[i].bytes != shares[j].bytes {
+			return shares[i].bytes > shares[j].bytes
+		}
+		return shares[i].name < shares[j].name
+	})
+	if len(shares) > languageCount {
+		shares = shares[:languageCount]
+	}
+	return shares
+}
+
+func readPrefix(path string, limit int64) string {
+	file, err := os.Open(path)
+	if err != nil {
+		return ""
+	}
+	defer file.Close()
+
+	data, err := io.ReadAll(io.LimitReader(file, limit))
+	if err != nil {
+		return ""
+	}
+	return string(data)
+}
+
+// timeAgo formats t relative to now, falling back to a date after a month.
+func timeAgo(t time.Time) string {
+	d := time.Since(t)
+	switch {
+	case d < time.Minute:
+		return "just now"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm ago", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh ago", int(d.Hours()))
+	case d < 30*24*time.Hour:
+		return fmt.Sprintf("%dd ago", int(d.Hours()/24))
+	}
+	return t.Format("02/01/2006")
+}
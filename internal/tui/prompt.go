@@ -0,0 +1,302 @@
+package tui
+
+import (
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/charmbracelet/bubbles/textinput"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+)
+
+type inputModel struct {
+	header      string
+	input       textinput.Model
+	validate    func(string) error
+	completion  bool
+	suggestions []string
+	err         error
+	done        bool
+	cancelled   bool
+}
+
+func (m inputModel) Init() tea.Cmd {
+	return textinput.Blink
+}
+
+func (m inputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc":
+			m.cancelled = true
+			return m, nil
+		case "enter":
+			value := strings.TrimSpace(m.input.Value())
+			if m.validate != nil {
+				if err := m.validate(value); err != nil {
+					m.err = err
+					return m, nil
+				}
+			}
+			m.done = true
+			return m, nil
+		case "tab":
+			if m.completion {
+				value, suggestions := completePath(m.input.Value())
+				m.input.SetValue(value)
+				m.input.CursorEnd()
+				m.suggestions = suggestions
+				return m, nil
+			}
+		}
+	}
+
+	var cmd tea.Cmd
+	m.input, cmd = m.input.Update(msg)
+	if _, ok := msg.(tea.KeyMsg); ok {
+		m.err = nil
+		if m.completion {
+			m.suggestions = nil
+		}
+	}
+	return m, cmd
+}
+
+func (m inputModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(promptHeaderStyle.Render(m.header) + "\n")
+	s.WriteString(m.input.View() + "\n")
+
+	if m.err != nil {
+		s.WriteString(promptErrorStyle.Render("✗ "+m.err.Error()) + "\n")
+	}
+
+	if len(m.suggestions) > 0 {
+		shown := m.suggestions
+		if len(shown) > 8 {
+			shown = shown[:8]
+		}
+		s.WriteString(promptHintStyle.Render("  "+strings.Join(shown, "  ")) + "\n")
+	}
+
+	hint := "enter submit • esc cancel"
+	if m.completion {
+		hint = "tab complete • " + hint
+	}
+	s.WriteString(promptHintStyle.Render(hint) + "\n")
+
+	return s.String()
+}
+
+func completePath(value string) (string, []string) {
+	expanded := value
+	home, _ := os.UserHomeDir()
+	if strings.HasPrefix(value, "~") && home != "" {
+		expanded = home + value[1:]
+	}
+
+	dir, prefix := filepath.Split(expanded)
+	searchDir := dir
+	if searchDir == "" {
+		searchDir = "."
+	}
+
+	entries, err := os.ReadDir(searchDir)
+	if err != nil {
+		return value, nil
+	}
+
+	var matches []string
+	for _, e := range entries {
+		if !e.IsDir() || !strings.HasPrefix(e.Name(), prefix) {
+			continue
+		}
+		if strings.HasPrefix(e.Name(), ".") && !strings.HasPrefix(prefix, ".") {
+			continue
+		}
+		matches = append(matches, e.Name()+"/")
+	}
+	sort.Strings(matches)
+
+	if len(matches) == 0 {
+		return value, nil
+	}
+
+	completed := matches[0]
+	for _, match := range matches[1:] {
+		completed = commonPrefix(completed, match)
+	}
+
+	// Keep "~" in what the user sees rather than the expanded home directory.
+	base := value[:len(value)-len(prefix)]
+	if len(matches) == 1 {
+		return base + completed, nil
+	}
+	return base + completed, matches
+}
+
+func commonPrefix(a, b string) string {
+	n := min(len(a), len(b))
+	for i := 0; i < n; i++ {
+		if a[i] != b[i] {
+			return a[:i]
+		}
+	}
+	return a[:n]
+}
+
+type confirmModel struct {
+	prompt      string
+	affirmative bool
+	done        bool
+	cancelled   bool
+}
+
+func (m confirmModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m confirmModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, nil
+		case "left", "right", "h", "l", "tab":
+			m.affirmative = !m.affirmative
+		case "y", "Y":
+			m.affirmative = true
+			m.done = true
+			return m, nil
+		case "n", "N":
+			m.affirmative = false
+			m.done = true
+			return m, nil
+		case "enter":
+			m.done = true
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m confirmModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	yes, no := actionButtonStyle.Render("Yes"), actionButtonStyle.Render("No")
+	if m.affirmative {
+		yes = activeButtonStyle.Render("Yes")
+	} else {
+		no = activeButtonStyle.Render("No")
+	}
+
+	return promptHeaderStyle.Render(m.prompt) + "\n\n" +
+		lipgloss.JoinHorizontal(lipgloss.Center, yes, no) + "\n\n" +
+		promptHintStyle.Render("←/→ toggle • y/n • enter submit") + "\n"
+}
+
+type selectModel struct {
+	header    string
+	items     []string
+	cursor    int
+	multi     bool
+	checked   map[int]bool
+	done      bool
+	cancelled bool
+}
+
+func (m selectModel) Init() tea.Cmd {
+	return nil
+}
+
+func (m selectModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	if msg, ok := msg.(tea.KeyMsg); ok {
+		switch msg.String() {
+		case "ctrl+c", "esc", "q":
+			m.cancelled = true
+			return m, nil
+		case "up", "k":
+			if m.cursor > 0 {
+				m.cursor--
+			}
+		case "down", "j":
+			if m.cursor < len(m.items)-1 {
+				m.cursor++
+			}
+		case "home", "g":
+			m.cursor = 0
+		case "end", "G":
+			m.cursor = len(m.items) - 1
+		case " ", "x":
+			if m.multi {
+				m.checked[m.cursor] = !m.checked[m.cursor]
+			}
+		case "a":
+			if m.multi {
+				all := len(m.selectedIndexes()) < len(m.items)
+				for i := range m.items {
+					m.checked[i] = all
+				}
+			}
+		case "enter":
+			m.done = true
+			return m, nil
+		}
+	}
+	return m, nil
+}
+
+func (m selectModel) selectedIndexes() []int {
+	var indexes []int
+	for i := range m.items {
+		if m.checked[i] {
+			indexes = append(indexes, i)
+		}
+	}
+	return indexes
+}
+
+func (m selectModel) View() string {
+	if m.done || m.cancelled {
+		return ""
+	}
+
+	var s strings.Builder
+	s.WriteString(promptHeaderStyle.Render(m.header) + "\n")
+
+	for i, item := range m.items {
+		cursor := "  "
+		if i == m.cursor {
+			cursor = promptCursorStyle.Render("> ")
+		}
+
+		box := ""
+		if m.multi {
+			box = "[ ] "
+			if m.checked[i] {
+				box = promptCursorStyle.Render("[x] ")
+			}
+		}
+
+		if i == m.cursor {
+			item = promptCursorStyle.Render(item)
+		}
+		s.WriteString(cursor + box + item + "\n")
+	}
+
+	hint := "↑/↓ move • enter select • esc cancel"
+	if m.multi {
+		hint = "↑/↓ move • space toggle • a all • enter confirm • esc cancel"
+	}
+	s.WriteString(promptHintStyle.Render(hint) + "\n")
+
+	return s.String()
+}
@@ -0,0 +1,207 @@
+package tui
+
+import (
+	"cmp"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/henrynguci/orbit/internal/config"
+)
+
+// Columns a table can be sorted by. Not every screen offers every column.
+const (
+	sortName      = "name"
+	sortWorkspace = "workspace"
+	sortStatus    = "status"
+	sortModified  = "modified"
+	sortActivity  = "activity"
+	sortFrecency  = "frecency"
+)
+
+var sortLabels = map[string]string{
+	sortName:      "name",
+	sortWorkspace: "workspace",
+	sortStatus:    "status",
+	sortModified:  "last modified",
+	sortActivity:  "git activity",
+	sortFrecency:  "frecency",
+}
+
+// tableSort is the sort order of one screen. It is saved in the config under
+// the screen's name so it survives restarts.
+type tableSort struct {
+	state   *appState
+	screen  string
+	columns []string
+	column  string
+	desc    bool
+}
+
+// sortFields holds what rows are compared by. Times are only filled in when
+// the current column needs them, since reading them touches the disk.
+type sortFields struct {
+	name      string
+	workspace string
+	status    string
+	rank      int
+	path      string
+	modified  time.Time
+	activity  time.Time
+	frecency  float64
+}
+
+func loadTableSort(state *appState, screen string, columns []string) tableSort {
+	s := tableSort{state: state, screen: screen, columns: columns, column: columns[0]}
+	s.desc = newestFirst(s.column)
+
+	saved, ok := state.cfg.Sort[screen]
+	if !ok {
+		return s
+	}
+	for _, c := range columns {
+		if c == saved.Column {
+			s.column = saved.Column
+			s.desc = saved.Desc
+		}
+	}
+	return s
+}
+
+// newestFirst reports whether a column starts in descending order: times
+// newest first and frecency highest first.
+func newestFirst(column string) bool {
+	return column == sortModified || column == sortActivity || column == sortFrecency
+}
+
+// next moves to the following column.
+func (s tableSort) next() tableSort {
+	for i, c := range s.columns {
+		if c == s.column {
+			s.column = s.columns[(i+1)%len(s.columns)]
+			break
+		}
+	}
+	s.desc = newestFirst(s.column)
+	return s
+}
+
+func (s tableSort) reversed() tableSort {
+	s.desc = !s.desc
+	return s
+}
+
+func (s tableSort) save() {
+	cfg := s.state.cfg
+	if cfg.Sort == nil {
+		cfg.Sort = make(map[string]config.SortOrder)
+	}
+	cfg.Sort[s.screen] = config.SortOrder{Column: s.column, Desc: s.desc}
+	config.Save(cfg)
+}
+
+func (s tableSort) label() string {
+	arrow := "↑"
+	if s.desc {
+		arrow = "↓"
+	}
+	return "sort: " + sortLabels[s.column] + " " + arrow
+}
+
+// fields builds the comparison fields for a row, reading times from disk
+// and scoring visits only when the current column sorts by them. Statuses
+// sort in workflow order.
+func (s tableSort) fields(name, workspace, status, path string) sortFields {
+	f := sortFields{name: name, workspace: workspace, status: status, path: path}
+	switch s.column {
+	case sortStatus:
+		f.rank = config.StatusRank(s.state.cfg, status)
+	case sortModified:
+		if info, err := os.Stat(path); err == nil {
+			f.modified = info.ModTime()
+		}
+	case sortActivity:
+		f.activity = lastCommitTime(path)
+	case sortFrecency:
+		f.frecency = s.state.visits[path].Score(time.Now())
+	}
+	return f
+}
+
+// less orders rows by the current column. Ties fall back to workspace, name
+// and path so the order never depends on map iteration.
+func (s tableSort) less(a, b sortFields) bool {
+	c := compareBy(a, b, s.column)
+	if s.desc {
+		c = -c
+	}
+	if c != 0 {
+		return c < 0
+	}
+
+	for _, column := range []string{sortWorkspace, sortName} {
+		if c := compareBy(a, b, column); c != 0 {
+			return c < 0
+		}
+	}
+	return a.path < b.path
+}
+
+func compareBy(a, b sortFields, column string) int {
+	switch column {
+	case sortName:
+		return strings.Compare(strings.ToLower(a.name), strings.ToLower(b.name))
+	case sortWorkspace:
+		return strings.Compare(strings.ToLower(a.workspace), strings.ToLower(b.workspace))
+	case sortStatus:
+		if c := cmp.Compare(a.rank, b.rank); c != 0 {
+			return c
+		}
+		return strings.Compare(a.status, b.status)
+	case sortModified:
+		return a.modified.Compare(b.modified)
+	case sortActivity:
+		return a.activity.Compare(b.activity)
+	case sortFrecency:
+		return cmp.Compare(a.frecency, b.frecency)
+	}
+	return 0
+}
+
+// sortItems returns a sorted copy of items, so slices shared with the config
+// are never reordered.
+func sortItems[T any](s tableSort, items []T, fields func(T) sortFields) []T {
+	keys := make([]sortFields, len(items))
+	order := make([]int, len(items))
+	for i, item := range items {
+		keys[i] = fields(item)
+		order[i] = i
+	}
+
+	sort.SliceStable(order, func(a, b int) bool {
+		return s.less(keys[order[a]], keys[order[b]])
+	})
+
+	sorted := make([]T, len(items))
+	for i, j := range order {
+		sorted[i] = items[j]
+	}
+	return sorted
+}
+
+// findRow returns the position in visible of the first row for which match
+// is true, so the cursor can follow an item when rows move.
+func findRow(visible []filteredRow, match func(index int) bool) (int, bool) {
+	for i, row := range visible {
+		if match(row.index) {
+			return i, true
+		}
+	}
+	return 0, false
+}
+
+// view shows the sort order, followed by extra such as the scroll position.
+func (s tableSort) view(extra string) string {
+	return promptHintStyle.Render("  "+s.label()+extra) + "\n"
+}
@@ -3,12 +3,14 @@ package tui
 import (
 	"fmt"
 
+	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
 	"github.com/henrynguci/orbit/internal/config"
+	"github.com/henrynguci/orbit/internal/hooks"
 )
 
-func handleChangeStatus(cfg *config.Config, projectName string) {
-	clearScreen()
+func handleChangeStatus(state *appState, projectName string) tea.Model {
+	cfg := state.cfg
 
 	project, exists := cfg.Projects[projectName]
 	if !exists {
@@ -21,49 +23,163 @@ func handleChangeStatus(cfg *config.Config, projectName string) {
 			}
 		}
 		if !exists {
-			printError("Project not found.")
-			waitForEnter()
-			return
+			return newErrorDialog("Change Status", "Project not found.")
 		}
 	}
 
-	currentStatus := project.Status
-	if currentStatus == "" {
-		currentStatus = "not set"
+	currentStatus := config.StatusOf(project)
+
+	body := fmt.Sprintf("Project: %s\nCurrent status: %s",
+		lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(projectName), renderStatus(cfg, currentStatus))
+
+	statusOptions := config.NextStatuses(cfg, currentStatus)
+	if len(statusOptions) == 0 {
+		return newMessageDialog("Change Status", fmt.Sprintf("%s\n\nNo status can follow '%s'.", body, currentStatus))
 	}
 
-	fmt.Println(titleStyle.Render("Change Status"))
-	fmt.Println()
-	fmt.Printf("Project: %s\n", lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(projectName))
-	fmt.Printf("Current status: %s\n", currentStatus)
-	fmt.Println()
+	return newSelectDialog("Change Status", body, "Select new status:", statusLabels(cfg, statusOptions), func(i int) tea.Cmd {
+		status := statusOptions[i]
+		prompt := fmt.Sprintf("%s\nNew status: %s", body, renderStatus(cfg, status))
+		return replaceScreen(newInputDialog("Change Status", prompt, "optional", "Note for the history:", nil, func(note string) tea.Cmd {
+			veto, toast := setProjectStatus(state, projectName, project, status, note)
+			if veto != nil {
+				return replaceScreen(veto)
+			}
+			return closeDialog(toast)
+		}))
+	})
+}
+
+// setProjectStatus is how every screen changes the status of one project:
+// the pre hook may veto the change, then the config is saved and the post
+// hook runs. The change is recorded in the history with note. It returns
+// either the dialog explaining a veto or the toast reporting the outcome.
+func setProjectStatus(state *appState, projectName string, project config.Project, status, note string) (tea.Model, tea.Cmd) {
+	cfg := state.cfg
 
-	var statusOptions []string
-	if currentStatus != "active" {
-		statusOptions = append(statusOptions, "active")
+	currentStatus := config.StatusOf(project)
+	if err := config.CheckTransition(cfg, currentStatus, status); err != nil {
+		return newErrorDialog("Change Status", fmt.Sprintf("Project '%s': %v", projectName, err)), nil
 	}
-	if currentStatus != "archived" {
-		statusOptions = append(statusOptions, "archived")
+
+	ev := projectEvent(cfg, projectName, project.Path)
+	ev.Name = hooks.EventStatus
+	ev.Status = status
+	ev.PreviousStatus = currentStatus
+	if veto := runPreHook(state, ev); veto != nil {
+		return veto, nil
 	}
-	if currentStatus != "done" {
-		statusOptions = append(statusOptions, "done")
+
+	project.Name = projectName
+	config.ChangeStatus(&project, status, note)
+	config.UpdateProject(cfg, project)
+	config.Save(cfg)
+
+	postErr := runPostHook(state, ev)
+	return nil, doneToast(fmt.Sprintf("Status changed to '%s' for project '%s'", status, projectName), postErr)
+}
+
+func handleBulkChangeStatus(state *appState, projectNames []string) tea.Model {
+	cfg := state.cfg
+
+	allProjects := config.GetAllProjects(cfg)
+	var projects []config.Project
+	for _, name := range projectNames {
+		if p, exists := cfg.Projects[name]; exists {
+			projects = append(projects, p)
+			continue
+		}
+		for _, p := range allProjects {
+			if p.Name == name {
+				projects = append(projects, p)
+				break
+			}
+		}
 	}
-	if currentStatus != "not set" {
-		statusOptions = append(statusOptions, "not set")
+
+	if len(projects) == 0 {
+		return newErrorDialog("Change Status", "No projects selected.")
 	}
 
-	newStatus, err := gumChoose(statusOptions, "Select new status:")
-	if err != nil || newStatus == "" {
-		return
+	items := make([]string, len(projects))
+	for i, p := range projects {
+		items[i] = fmt.Sprintf("%s (%s)", p.Name, config.StatusOf(p))
 	}
 
-	statusValue := newStatus
+	header := fmt.Sprintf("%d projects selected:", len(projects))
+	return newMultiSelectDialog("Change Status", "", header, items, func(indexes []int) tea.Cmd {
+		var selected []config.Project
+		for _, i := range indexes {
+			selected = append(selected, projects[i])
+		}
 
-	project.Name = projectName
-	project.Status = statusValue
-	cfg.Projects[projectName] = project
+		statuses := config.StatusNames(cfg)
+		return replaceScreen(newSelectDialog("Change Status", "", "Select new status:", statusLabels(cfg, statuses), func(i int) tea.Cmd {
+			newStatus := statuses[i]
+
+			prompt := fmt.Sprintf("Set %d projects to '%s'?", len(selected), newStatus)
+			return replaceScreen(newConfirmDialog("Change Status", "", prompt, func(yes bool) tea.Cmd {
+				if !yes {
+					return cancelDialog()
+				}
+				return applyBulkStatus(state, selected, newStatus)
+			}))
+		}))
+	})
+}
+
+func applyBulkStatus(state *appState, projects []config.Project, newStatus string) tea.Cmd {
+	cfg := state.cfg
+
+	var changed []hooks.Event
+	var vetoed []string
+	for _, p := range projects {
+		if err := config.CheckTransition(cfg, p.Status, newStatus); err != nil {
+			vetoed = append(vetoed, fmt.Sprintf("%s: %v", p.Name, err))
+			continue
+		}
+
+		ev := projectEvent(cfg, p.Name, p.Path)
+		ev.Name = hooks.EventStatus
+		ev.Status = newStatus
+		ev.PreviousStatus = p.Status
+		if err := hooks.Pre(cfg, ev); err != nil {
+			vetoed = append(vetoed, err.Error())
+			continue
+		}
+
+		config.ChangeStatus(&p, newStatus, "")
+		config.UpdateProject(cfg, p)
+		changed = append(changed, ev)
+	}
 	config.Save(cfg)
 
-	printSuccess(fmt.Sprintf("Status changed to '%s' for project '%s'", statusValue, projectName))
-	waitForEnter()
+	var postErr error
+	for _, ev := range changed {
+		if err := runPostHook(state, ev); err != nil && postErr == nil {
+			postErr = err
+		}
+	}
+
+	msg := fmt.Sprintf("Status changed to '%s' for %d projects", newStatus, len(changed))
+	if len(vetoed) > 0 {
+		body := fmt.Sprintf("%s; %d not allowed or vetoed by hooks:\n\n", msg, len(vetoed))
+		for _, v := range vetoed {
+			body += v + "\n"
+		}
+		return tea.Sequence(replaceScreen(newErrorDialog("Change Status", body)), reloadConfig)
+	}
+	return closeDialog(doneToast(msg, postErr))
+}
+
+// statusLabels returns the statuses with their icons, for dialogs.
+func statusLabels(cfg *config.Config, statuses []string) []string {
+	labels := make([]string, len(statuses))
+	for i, name := range statuses {
+		labels[i] = name
+		if s, ok := config.LookupStatus(cfg, name); ok {
+			labels[i] = s.Label()
+		}
+	}
+	return labels
 }
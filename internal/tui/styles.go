@@ -0,0 +1,134 @@
+package tui
+
+import (
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/theme"
+)
+
+// Colours and styles used across the TUI. They follow the current theme and
+// are rebuilt by applyTheme whenever it changes.
+var (
+	primaryColor   lipgloss.TerminalColor
+	secondaryColor lipgloss.TerminalColor
+	successColor   lipgloss.TerminalColor
+	warningColor   lipgloss.TerminalColor
+	errorColor     lipgloss.TerminalColor
+	mutedColor     lipgloss.TerminalColor
+	textColor      lipgloss.TerminalColor
+	highlightColor lipgloss.TerminalColor
+	accentColor    lipgloss.TerminalColor
+
+	greenBtn  lipgloss.Style
+	redBtn    lipgloss.Style
+	blueBtn   lipgloss.Style
+	purpleBtn lipgloss.Style
+	yellowBtn lipgloss.Style
+
+	selectedStyle     lipgloss.Style
+	actionButtonStyle lipgloss.Style
+	activeButtonStyle lipgloss.Style
+	dangerButtonStyle lipgloss.Style
+	titleStyle        lipgloss.Style
+	subtitleStyle     lipgloss.Style
+	hintStyle         lipgloss.Style
+	boxStyle          lipgloss.Style
+
+	promptHeaderStyle lipgloss.Style
+	promptCursorStyle lipgloss.Style
+	promptHintStyle   lipgloss.Style
+	promptErrorStyle  lipgloss.Style
+
+	matchStyle  lipgloss.Style
+	filterStyle lipgloss.Style
+
+	panelStyle   lipgloss.Style
+	sectionStyle lipgloss.Style
+	sessionStyle lipgloss.Style
+	labelStyle   lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(applyTheme)
+	applyTheme()
+}
+
+func applyTheme() {
+	p := theme.Current().Palette
+
+	primaryColor = theme.Color(p.Primary)
+	secondaryColor = theme.Color(p.Secondary)
+	successColor = theme.Color(p.Success)
+	warningColor = theme.Color(p.Warning)
+	errorColor = theme.Color(p.Error)
+	mutedColor = theme.Color(p.Muted)
+	textColor = theme.Color(p.Text)
+	highlightColor = theme.Color(p.Highlight)
+	accentColor = theme.Color(p.Accent)
+
+	button := func(bg string) lipgloss.Style {
+		return theme.Filled(p.ButtonText, bg).
+			Padding(0, 2).
+			MarginRight(1).
+			Bold(true)
+	}
+	greenBtn = button(p.Green)
+	redBtn = button(p.Red)
+	blueBtn = button(p.Blue)
+	purpleBtn = button(p.Purple)
+	yellowBtn = button(p.Yellow)
+
+	selectedStyle = theme.Filled(p.Highlight, p.Primary).
+		Bold(true).
+		Padding(0, 1)
+
+	actionButtonStyle = lipgloss.NewStyle().
+		Foreground(textColor).
+		Background(theme.Color(p.Surface)).
+		Padding(0, 2).
+		Margin(0, 1)
+
+	activeButtonStyle = theme.Filled(p.Highlight, p.Primary).
+		Bold(true).
+		Padding(0, 2).
+		Margin(0, 1)
+
+	dangerButtonStyle = theme.Filled(p.Highlight, p.Error).
+		Bold(true).
+		Padding(0, 2).
+		Margin(0, 1)
+
+	titleStyle = theme.Filled(p.Highlight, p.Primary).
+		Bold(true).
+		Padding(1, 4).
+		Width(100).
+		Align(lipgloss.Center).
+		MarginBottom(1)
+
+	subtitleStyle = lipgloss.NewStyle().
+		Foreground(mutedColor).
+		Italic(true)
+
+	hintStyle = lipgloss.NewStyle().
+		Foreground(mutedColor)
+
+	boxStyle = lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(primaryColor).
+		Padding(1, 2)
+
+	promptHeaderStyle = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+	promptCursorStyle = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+	promptHintStyle = lipgloss.NewStyle().Foreground(mutedColor)
+	promptErrorStyle = lipgloss.NewStyle().Foreground(errorColor)
+
+	matchStyle = lipgloss.NewStyle().Foreground(warningColor).Bold(true).Underline(true)
+	filterStyle = lipgloss.NewStyle().Foreground(secondaryColor)
+
+	panelStyle = lipgloss.NewStyle().
+		Border(theme.Border()).
+		BorderForeground(mutedColor).
+		Padding(0, 1)
+	sectionStyle = lipgloss.NewStyle().Foreground(primaryColor).Bold(true)
+	sessionStyle = lipgloss.NewStyle().Foreground(secondaryColor).Bold(true)
+	labelStyle = lipgloss.NewStyle().Foreground(mutedColor).Width(10)
+}
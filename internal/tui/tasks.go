@@ -0,0 +1,210 @@
+package tui
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os/exec"
+	"strings"
+	"syscall"
+
+	"github.com/charmbracelet/bubbles/viewport"
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/frecency"
+	"github.com/henrynguci/orbit/internal/tasks"
+)
+
+type taskLineMsg string
+
+type taskDoneMsg struct {
+	err error
+}
+
+// taskOutputModel streams the output of a command into a scrollable viewport.
+// It is used for tasks and for plugin menu entries.
+type taskOutputModel struct {
+	title    string
+	command  string
+	cmd      *exec.Cmd
+	writer   *io.PipeWriter
+	lines    chan string
+	done     chan error
+	quit     chan struct{}
+	output   []string
+	viewport viewport.Model
+	ready    bool
+	finished bool
+	err      error
+}
+
+func (m taskOutputModel) Init() tea.Cmd {
+	return m.waitForOutput
+}
+
+func (m taskOutputModel) waitForOutput() tea.Msg {
+	line, ok := <-m.lines
+	if !ok {
+		return taskDoneMsg{err: <-m.done}
+	}
+	return taskLineMsg(line)
+}
+
+func (m taskOutputModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
+	var cmd tea.Cmd
+
+	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		height := msg.Height - 6
+		if !m.ready {
+			m.viewport = viewport.New(msg.Width, height)
+			m.ready = true
+		} else {
+			m.viewport.Width = msg.Width
+			m.viewport.Height = height
+		}
+		m.viewport.SetContent(strings.Join(m.output, "\n"))
+		m.viewport.GotoBottom()
+	case taskLineMsg:
+		atBottom := m.viewport.AtBottom()
+		m.output = append(m.output, string(msg))
+		m.viewport.SetContent(strings.Join(m.output, "\n"))
+		if atBottom {
+			m.viewport.GotoBottom()
+		}
+		return m, m.waitForOutput
+	case taskDoneMsg:
+		m.finished = true
+		m.err = msg.err
+		return m, nil
+	case tea.KeyMsg:
+		switch msg.String() {
+		case "ctrl+c":
+			if !m.finished {
+				m.kill()
+				return m, nil
+			}
+			return m, popScreen
+		case "q", "esc", "r":
+			if m.finished {
+				return m, popScreen
+			}
+		}
+	}
+
+	m.viewport, cmd = m.viewport.Update(msg)
+	return m, cmd
+}
+
+// kill stops the command together with everything it started, such as the
+// dev server behind "npm run dev", which shares its process group.
+func (m taskOutputModel) kill() {
+	if m.cmd.Process != nil {
+		syscall.Kill(-m.cmd.Process.Pid, syscall.SIGKILL)
+	}
+}
+
+// stop kills a command that is still running when the program exits, and
+// releases the goroutines reading its output.
+func (m taskOutputModel) stop() {
+	if m.finished {
+		return
+	}
+	m.kill()
+	close(m.quit)
+	m.writer.Close()
+}
+
+func (m taskOutputModel) View() string {
+	if !m.ready {
+		return "\n  Starting..."
+	}
+
+	header := renderTitle(m.title, m.viewport.Width) + "\n" +
+		lipgloss.NewStyle().Foreground(mutedColor).Italic(true).Render("$ "+m.command)
+
+	var status string
+	switch {
+	case !m.finished:
+		status = lipgloss.NewStyle().Foreground(secondaryColor).Render("● running — ctrl+c to stop")
+	case m.err != nil:
+		status = lipgloss.NewStyle().Foreground(errorColor).Bold(true).Render(fmt.Sprintf("✗ %v", m.err)) + "   " + yellowBtn.Render("r Return")
+	default:
+		status = lipgloss.NewStyle().Foreground(successColor).Bold(true).Render("✓ finished") + "   " + yellowBtn.Render("r Return")
+	}
+
+	return header + "\n" + m.viewport.View() + "\n" + status
+}
+
+func newOutputScreen(title, command string, cmd *exec.Cmd) (tea.Model, error) {
+	reader, writer := io.Pipe()
+	cmd.Stdout = writer
+	cmd.Stderr = writer
+	cmd.SysProcAttr = &syscall.SysProcAttr{Setpgid: true}
+
+	m := taskOutputModel{
+		title:   title,
+		command: command,
+		cmd:     cmd,
+		writer:  writer,
+		lines:   make(chan string),
+		done:    make(chan error, 1),
+		quit:    make(chan struct{}),
+	}
+
+	if err := cmd.Start(); err != nil {
+		return nil, err
+	}
+
+	go func() {
+		scanner := bufio.NewScanner(reader)
+		for scanner.Scan() {
+			select {
+			case m.lines <- scanner.Text():
+			case <-m.quit:
+				// Nobody reads the lines any more; closing the reader
+				// unblocks the command writing to the pipe.
+				reader.Close()
+				return
+			}
+		}
+		close(m.lines)
+	}()
+
+	go func() {
+		err := cmd.Wait()
+		writer.Close()
+		m.done <- err
+	}()
+
+	return m, nil
+}
+
+func runTask(projectPath string, task tasks.Task) tea.Model {
+	screen, err := newOutputScreen("Task: "+task.Name, task.Cmd, tasks.Command(projectPath, task))
+	if err != nil {
+		return newErrorDialog("Tasks", fmt.Sprintf("Failed to start task: %v", err))
+	}
+	return screen
+}
+
+func handleRunTask(projectPath string) tea.Model {
+	projectTasks, err := tasks.Resolve(projectPath)
+	if err != nil {
+		return newErrorDialog("Tasks", fmt.Sprintf("Failed to load tasks: %v", err))
+	}
+
+	if len(projectTasks) == 0 {
+		return newMessageDialog("Tasks", "No tasks found. Define them in orbit.json in the project directory.")
+	}
+
+	items := make([]string, len(projectTasks))
+	for i, t := range projectTasks {
+		items[i] = fmt.Sprintf("%s │ %s", t.Name, t.Cmd)
+	}
+
+	return newSelectDialog("Tasks", "", "Select task to run:", items, func(i int) tea.Cmd {
+		frecency.Visit(projectPath)
+		return replaceScreen(runTask(projectPath, projectTasks[i]))
+	})
+}
@@ -0,0 +1,32 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/henrynguci/orbit/internal/timelog"
+	"github.com/henrynguci/orbit/internal/utils"
+)
+
+// toggleTracking stops the running session if it is on the project, and
+// otherwise starts one there.
+func toggleTracking(state *appState, projectName, path string) tea.Cmd {
+	var msg string
+	if s := state.session; s != nil && s.Path == path {
+		stopped, err := timelog.Stop()
+		if err != nil {
+			return showErrorToast(fmt.Sprintf("Failed to stop the session: %v", err))
+		}
+		if stopped != nil {
+			msg = fmt.Sprintf("Stopped '%s' after %s", stopped.Project, utils.FormatHours(stopped.Duration(stopped.End)))
+		}
+	} else {
+		if _, err := timelog.Start(projectName, path, false); err != nil {
+			return showErrorToast(fmt.Sprintf("Failed to start the session: %v", err))
+		}
+		msg = fmt.Sprintf("Tracking time on '%s'", projectName)
+	}
+
+	state.loadTime()
+	return showToast(msg)
+}
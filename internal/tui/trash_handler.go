@@ -0,0 +1,34 @@
+package tui
+
+import (
+	"fmt"
+
+	tea "github.com/charmbracelet/bubbletea"
+	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/trash"
+)
+
+func handleUndoDelete() tea.Cmd {
+	entry, err := trash.Last()
+	if err != nil {
+		return showToast("Nothing to undo.")
+	}
+
+	body := fmt.Sprintf("Last deleted %s: %s\nPath: %s\nDeleted: %s",
+		entry.Kind,
+		lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(entry.Name),
+		entry.OriginalPath,
+		entry.DeletedAt.Format("02/01/2006 15:04"))
+
+	return pushScreen(newConfirmDialog("Undo Delete", body, fmt.Sprintf("Restore %s '%s'?", entry.Kind, entry.Name), func(yes bool) tea.Cmd {
+		if !yes {
+			return cancelDialog()
+		}
+
+		if _, err := trash.Restore(entry.ID); err != nil {
+			return replaceScreen(newErrorDialog("Undo Delete", fmt.Sprintf("Restore failed: %v", err)))
+		}
+
+		return closeDialog(showToast(fmt.Sprintf("Restored %s '%s'", entry.Kind, entry.Name)))
+	}))
+}
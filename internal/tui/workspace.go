@@ -1,18 +1,112 @@
 package tui
 
 import (
+	"fmt"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
 	tea "github.com/charmbracelet/bubbletea"
 	"github.com/charmbracelet/lipgloss"
-	"github.com/charmbracelet/lipgloss/table"
+	"github.com/henrynguci/orbit/internal/config"
 )
 
 type lipglossWorkspaceModel struct {
+	state      *appState
 	workspaces []string
+	visible    []filteredRow
+	filter     tableFilter
+	sort       tableSort
 	cursor     int
-	selected   string
-	action     string
-	quitting   bool
+	offset     int
 	showBanner bool
+	recent     []config.Project
+}
+
+// recentCount is how many of the most visited projects the workspace screen
+// lists above the workspaces.
+const recentCount = 5
+
+func newWorkspaceScreen(state *appState) lipglossWorkspaceModel {
+	m := lipglossWorkspaceModel{
+		state:      state,
+		filter:     newTableFilter(),
+		sort:       loadTableSort(state, "workspaces", []string{sortName, sortModified}),
+		showBanner: true,
+	}
+	m.setWorkspaces(state.cfg.Workspaces)
+	m.setRecent()
+	return m
+}
+
+// setRecent picks the projects with the highest frecency.
+func (m *lipglossWorkspaceModel) setRecent() {
+	now := time.Now()
+	m.recent = nil
+	for _, p := range config.GetAllProjects(m.state.cfg) {
+		if m.state.visits[p.Path].Rank > 0 {
+			m.recent = append(m.recent, p)
+		}
+	}
+	sort.SliceStable(m.recent, func(i, j int) bool {
+		return m.state.visits[m.recent[i].Path].Score(now) > m.state.visits[m.recent[j].Path].Score(now)
+	})
+	m.recent = m.recent[:min(len(m.recent), recentCount)]
+}
+
+// recentIndex returns which recent project a digit key picks.
+func (m lipglossWorkspaceModel) recentIndex(msg tea.KeyMsg) (int, bool) {
+	i, err := strconv.Atoi(msg.String())
+	if err != nil || i < 1 || i > len(m.recent) {
+		return 0, false
+	}
+	return i - 1, true
+}
+
+// setWorkspaces sorts and filters workspaces, keeping the cursor on the same
+// workspace if it is still shown.
+func (m *lipglossWorkspaceModel) setWorkspaces(workspaces []string) {
+	current, hadCurrent := m.current()
+
+	m.workspaces = sortItems(m.sort, workspaces, func(w string) sortFields {
+		return m.sort.fields(filepath.Base(w), "", "", w)
+	})
+	m.applyFilter()
+
+	if hadCurrent {
+		m.selectWorkspace(current)
+	}
+}
+
+// selectWorkspace moves the cursor to workspace, reporting whether it is
+// shown.
+func (m *lipglossWorkspaceModel) selectWorkspace(workspace string) bool {
+	i, ok := findRow(m.visible, func(index int) bool { return m.workspaces[index] == workspace })
+	if ok {
+		m.cursor = i
+		m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+	}
+	return ok
+}
+
+func (m *lipglossWorkspaceModel) applyFilter() {
+	m.visible = m.filter.apply(len(m.workspaces), func(i int) []string {
+		w := m.workspaces[i]
+		return []string{fieldName: filepath.Base(w)}
+	})
+	m.cursor = clampCursor(m.cursor, len(m.visible))
+	m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+}
+
+// current returns the workspace under the cursor, looked up through the
+// filter.
+func (m lipglossWorkspaceModel) current() (string, bool) {
+	if len(m.visible) == 0 {
+		return "", false
+	}
+	return m.workspaces[m.visible[m.cursor].index], true
 }
 
 func (m lipglossWorkspaceModel) Init() tea.Cmd {
@@ -20,179 +114,212 @@ func (m lipglossWorkspaceModel) Init() tea.Cmd {
 }
 
 func (m lipglossWorkspaceModel) Update(msg tea.Msg) (tea.Model, tea.Cmd) {
-	var cmd tea.Cmd
 	switch msg := msg.(type) {
+	case tea.WindowSizeMsg:
+		m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+	case configChangedMsg:
+		m.setWorkspaces(m.state.cfg.Workspaces)
+		m.setRecent()
 	case tea.KeyMsg:
-		switch msg.String() {
-		case "q", "ctrl+c":
-			m.quitting = true
-			m.action = "quit"
-			return m, tea.Quit
-		case "c":
-			m.action = "create"
-			return m, tea.Quit
-		case "d":
-			if len(m.workspaces) > 0 {
-				m.selected = m.workspaces[m.cursor]
-				m.action = "delete"
-				return m, tea.Quit
-			}
-		case "h":
-			m.action = "dashboard"
+		keys := m.state.keys
+
+		var handled bool
+		var cmd tea.Cmd
+		if m.filter, handled, cmd = m.filter.update(keys, msg); handled {
+			m.applyFilter()
+			return m, cmd
+		}
+
+		if cursor, ok := navigate(keys, msg, m.cursor, len(m.visible), m.pageSize()); ok {
+			m.cursor = cursor
+			m.offset = scrollOffset(m.offset, m.cursor, len(m.visible), m.pageSize())
+			return m, nil
+		}
+
+		if i, ok := m.recentIndex(msg); ok {
+			m.showBanner = false
+			return m, pushScreen(newProjectDetailScreen(m.state, m.recent[i]))
+		}
+
+		switch {
+		case keys.matches(msg, keyQuit):
 			return m, tea.Quit
-		case "g":
-			if len(m.workspaces) > 0 {
-				m.selected = m.workspaces[m.cursor]
-				m.action = "goto"
-				return m, tea.Quit
+		case keys.matches(msg, keyHelp):
+			return m, pushScreen(newHelpScreen(m.state, "Workspaces", navigationHelp, helpGroup{
+				title:   "Workspaces",
+				actions: []string{keySelect, keyCreate, keyDelete, keyGoto, keyDash, keyBoard, keyUndo, keyHelp, keyQuit},
+			}))
+		case keys.matches(msg, keyCreate):
+			m.showBanner = false
+			return m, pushScreen(handleCreateWorkspace(m.state))
+		case keys.matches(msg, keyDelete):
+			if w, ok := m.current(); ok {
+				m.showBanner = false
+				return m, pushScreen(handleDeleteWorkspace(m.state, w))
 			}
-		case "enter":
-			if len(m.workspaces) > 0 {
-				m.selected = m.workspaces[m.cursor]
-				m.action = "select"
-				return m, tea.Quit
+		case keys.matches(msg, keyDash):
+			m.showBanner = false
+			return m, pushScreen(newDashboardScreen(m.state))
+		case keys.matches(msg, keyBoard):
+			m.showBanner = false
+			return m, pushScreen(newBoardScreen(m.state))
+		case keys.matches(msg, keyUndo):
+			return m, handleUndoDelete()
+		case keys.matches(msg, keyGoto):
+			if w, ok := m.current(); ok {
+				return m, gotoDirectory(m.state, w)
 			}
-		case "up", "k":
-			if m.cursor > 0 {
-				m.cursor--
-			}
-		case "down", "j":
-			if m.cursor < len(m.workspaces)-1 {
-				m.cursor++
+		case keys.matches(msg, keySelect):
+			if w, ok := m.current(); ok {
+				m.showBanner = false
+				return m, pushScreen(newProjectScreen(m.state, w))
 			}
+		case keys.matches(msg, keySort):
+			m.sort = m.sort.next()
+			m.sort.save()
+			m.setWorkspaces(m.workspaces)
+		case keys.matches(msg, keyReverse):
+			m.sort = m.sort.reversed()
+			m.sort.save()
+			m.setWorkspaces(m.workspaces)
 		}
 	}
-	return m, cmd
+	return m, nil
 }
 
-func (m lipglossWorkspaceModel) View() string {
-	if m.quitting {
-		return ""
+func (m lipglossWorkspaceModel) columns() []column {
+	return []column{
+		{title: "Workspace", width: 18, min: 8},
+		{title: "Last Modified", width: 18, min: 16, drop: 1},
+		{title: "Path", width: 53, min: 12, drop: 2, flex: true},
 	}
+}
+
+// header renders everything above the table. info is the scroll position,
+// which does not change how many lines the header takes.
+func (m lipglossWorkspaceModel) header(info string) string {
+	width := screenWidth(m.state.width)
 
 	var s string
 	if m.showBanner {
-
-		bannerText := lipgloss.NewStyle().Foreground(lipgloss.Color("#E06C75")).Bold(true).Render(orbitBanner)
-		centeredBanner := lipgloss.PlaceHorizontal(100, lipgloss.Center, bannerText)
+		bannerText := lipgloss.NewStyle().Foreground(accentColor).Bold(true).Render(orbitBanner)
+		centeredBanner := lipgloss.PlaceHorizontal(width, lipgloss.Center, bannerText)
 		s += centeredBanner + "\n"
 
-		subtitle := lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Italic(true).Render("Keep your side projects in orbit 🚀")
-		centeredSubtitle := lipgloss.PlaceHorizontal(100, lipgloss.Center, subtitle)
+		subtitle := subtitleStyle.Render("Keep your side projects in orbit 🚀")
+		centeredSubtitle := lipgloss.PlaceHorizontal(width, lipgloss.Center, subtitle)
 		s += centeredSubtitle + "\n\n"
 	} else {
 		s += "\n"
 	}
-	s += titleStyle.Render("Workspaces") + "\n\n"
+	s += m.recentSection()
+	s += renderTitle("Workspaces", m.state.width) + "\n\n"
 
+	s += m.filter.view(len(m.visible), len(m.workspaces), "workspaces")
 	if len(m.workspaces) > 0 {
-		s += m.renderTable() + "\n\n"
-	} else {
-		s += lipgloss.NewStyle().Foreground(mutedColor).Render("  No workspaces found. Press 'c' to create one.") + "\n\n"
+		s += m.sort.view(info)
 	}
+	return s
+}
 
-	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
-		greenBtn.Render("c Create"),
-		redBtn.Render("d Delete"),
-		blueBtn.Render("g Goto"),
-		blueBtn.Render("h Dashboard"),
-		purpleBtn.Render("q Quit"),
-	)
-	s += helpBar + "\n"
+// recentSection lists the most visited projects, each with the digit that
+// opens it.
+func (m lipglossWorkspaceModel) recentSection() string {
+	if len(m.recent) == 0 {
+		return ""
+	}
 
-	if len(m.workspaces) > 0 {
-		s += lipgloss.NewStyle().Foreground(lipgloss.Color("#5C6370")).Render("\n  ↑/↓: Navigate  Enter: Select") + "\n"
+	nameWidth, workspaceWidth := 0, 0
+	for _, p := range m.recent {
+		nameWidth = max(nameWidth, len(p.Name))
+		workspaceWidth = max(workspaceWidth, len(filepath.Base(config.WorkspaceOf(m.state.cfg, p))))
 	}
 
-	return s
+	s := "  " + sectionStyle.Render("Recent") + "\n"
+	for i, p := range m.recent {
+		workspace := filepath.Base(config.WorkspaceOf(m.state.cfg, p))
+		line := fmt.Sprintf("  %s  %-*s  %s  %s",
+			lipgloss.NewStyle().Foreground(secondaryColor).Bold(true).Render(strconv.Itoa(i+1)),
+			nameWidth, p.Name,
+			promptHintStyle.Render(fmt.Sprintf("%-*s", workspaceWidth, workspace)),
+			promptHintStyle.Render(timeAgo(m.state.visits[p.Path].LastVisit)))
+		s += lipgloss.NewStyle().MaxWidth(screenWidth(m.state.width)).Render(line) + "\n"
+	}
+	return s + "\n"
 }
 
-func (m lipglossWorkspaceModel) renderTable() string {
-
-	const (
-		workspaceWidth = 20
-		lastModWidth   = 20
-		pathWidth      = 55
+func (m lipglossWorkspaceModel) footer() string {
+	keys := m.state.keys
+	helpBar := lipgloss.JoinHorizontal(lipgloss.Center,
+		greenBtn.Render(keys.label(keyCreate)+" Create"),
+		redBtn.Render(keys.label(keyDelete)+" Delete"),
+		blueBtn.Render(keys.label(keyGoto)+" Goto"),
+		blueBtn.Render(keys.label(keyDash)+" Dashboard"),
+		blueBtn.Render(keys.label(keyBoard)+" Board"),
+		yellowBtn.Render(keys.label(keyUndo)+" Undo"),
+		purpleBtn.Render(keys.label(keyQuit)+" Quit"),
 	)
+	s := lipgloss.NewStyle().MaxWidth(screenWidth(m.state.width)).Render(helpBar) + "\n"
 
-
-	var rows [][]string
-	for _, w := range m.workspaces {
-		name := truncateString(w[len(w)-len(w):], workspaceWidth)
-
-		if idx := len(w); idx > 0 {
-
-			for i := len(w) - 1; i >= 0; i-- {
-				if w[i] == '/' {
-					name = w[i+1:]
-					break
-				}
-			}
+	if len(m.workspaces) > 0 {
+		hints := []string{
+			keys.hint("Navigate", keyUp, keyDown),
+			keys.hint("Page", keyPageUp, keyPageDown),
+			keys.hint("Select", keySelect),
 		}
-
-		lastMod := getLastModifiedTime(w)
-		path := truncateString(w, pathWidth)
-
-		rows = append(rows, []string{
-			name,
-			lastMod,
-			path,
-		})
+		switch len(m.recent) {
+		case 0:
+		case 1:
+			hints = append(hints, "1: Recent")
+		default:
+			hints = append(hints, fmt.Sprintf("1-%d: Recent", len(m.recent)))
+		}
+		hints = append(hints,
+			keys.hint("Filter", keyFilter),
+			keys.hint("Sort", keySort, keyReverse),
+			keys.hint("Help", keyHelp),
+		)
+		s += hintStyle.MaxWidth(screenWidth(m.state.width)).Render("\n  "+strings.Join(hints, "  ")) + "\n"
 	}
+	return s
+}
 
+func (m lipglossWorkspaceModel) pageSize() int {
+	return pageSize(m.state.height, m.header(""), m.footer())
+}
 
-	t := table.New().
-		Border(lipgloss.RoundedBorder()).
-		BorderStyle(lipgloss.NewStyle().Foreground(primaryColor)).
-		Headers("Workspace", "Last Modified", "Path").
-		Rows(rows...).
-		StyleFunc(func(row, col int) lipgloss.Style {
-			var width int
-			switch col {
-			case 0:
-				width = workspaceWidth
-			case 1:
-				width = lastModWidth
-			case 2:
-				width = pathWidth
-			}
-
-			style := lipgloss.NewStyle().Width(width).Padding(0, 1)
-
+func (m lipglossWorkspaceModel) View() string {
+	page := m.pageSize()
+	s := m.header(scrollInfo(scrollOffset(m.offset, m.cursor, len(m.visible), page), page, len(m.visible)))
 
-			if row == table.HeaderRow {
-				return style.
-					Bold(true).
-					Foreground(primaryColor).
-					BorderForeground(primaryColor).
-					Align(lipgloss.Left)
-			}
+	if len(m.visible) > 0 {
+		s += m.renderTable() + "\n\n"
+	} else if len(m.workspaces) > 0 {
+		s += hintStyle.Render("  No workspaces match the filter.") + "\n\n"
+	} else {
+		s += hintStyle.Render("  No workspaces found. Press 'c' to create one.") + "\n\n"
+	}
 
+	return s + m.footer()
+}
 
-			if row == m.cursor {
-				return style.
-					Background(primaryColor).
-					Foreground(whiteColor).
-					Bold(true)
-			}
+func (m lipglossWorkspaceModel) renderTable() string {
+	columns := m.columns()
+	widths := fitColumns(columns, m.state.width)
 
+	page := m.pageSize()
+	offset := scrollOffset(m.offset, m.cursor, len(m.visible), page)
+	end := min(offset+page, len(m.visible))
 
-			return style.Foreground(whiteColor)
+	var rows [][]string
+	for _, v := range m.visible[offset:end] {
+		w := m.workspaces[v.index]
+		rows = append(rows, []string{
+			highlightMatches(filepath.Base(w), cellMatch(v, fieldName), 0, max(widths[0]-2, 4)),
+			cellText(getLastModifiedTime(w), widths[1]),
+			cellText(w, widths[2]),
 		})
-
-	return t.Render()
-}
-
-func selectWorkspaceLipgloss(workspaces []string, showBanner bool) (string, string) {
-	m := lipglossWorkspaceModel{
-		workspaces: workspaces,
-		showBanner: showBanner,
-		cursor:     0,
 	}
 
-	p := tea.NewProgram(m, tea.WithAltScreen())
-	finalModel, _ := p.Run()
-	result := finalModel.(lipglossWorkspaceModel)
-
-	return result.selected, result.action
+	return renderTable(columns, widths, rows, m.cursor-offset, nil)
 }
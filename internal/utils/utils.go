@@ -2,62 +2,208 @@ package utils
 
 import (
 	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strconv"
+	"syscall"
+	"time"
 
 	"github.com/charmbracelet/lipgloss"
+	"github.com/henrynguci/orbit/internal/theme"
 )
 
+// Colours and styles for CLI output. They follow the current theme and are
+// rebuilt whenever it changes.
 var (
+	PrimaryColor   lipgloss.TerminalColor
+	SecondaryColor lipgloss.TerminalColor
+	SuccessColor   lipgloss.TerminalColor
+	ErrorColor     lipgloss.TerminalColor
+	WarningColor   lipgloss.TerminalColor
+	InfoColor      lipgloss.TerminalColor
+	MutedColor     lipgloss.TerminalColor
+
+	SuccessStyle  lipgloss.Style
+	ErrorStyle    lipgloss.Style
+	WarningStyle  lipgloss.Style
+	InfoStyle     lipgloss.Style
+	MutedStyle    lipgloss.Style
+	TitleStyle    lipgloss.Style
+	SubtitleStyle lipgloss.Style
+)
+
+func init() {
+	theme.OnChange(applyTheme)
+	applyTheme()
+}
 
-	PrimaryColor   = lipgloss.Color("#7C3AED")
-	SecondaryColor = lipgloss.Color("#06B6D4")
-	SuccessColor   = lipgloss.Color("#10B981")
-	ErrorColor     = lipgloss.Color("#EF4444")
-	WarningColor   = lipgloss.Color("#F59E0B")
-	InfoColor      = lipgloss.Color("#3B82F6")
-	MutedColor     = lipgloss.Color("#6B7280")
+func applyTheme() {
+	p := theme.Current().Palette
 
+	PrimaryColor = theme.Color(p.Primary)
+	SecondaryColor = theme.Color(p.Secondary)
+	SuccessColor = theme.Color(p.Success)
+	ErrorColor = theme.Color(p.Error)
+	WarningColor = theme.Color(p.Warning)
+	InfoColor = theme.Color(p.Info)
+	MutedColor = theme.Color(p.Muted)
 
 	SuccessStyle = lipgloss.NewStyle().
-			Foreground(SuccessColor).
-			Bold(true)
+		Foreground(SuccessColor).
+		Bold(true)
 
 	ErrorStyle = lipgloss.NewStyle().
-			Foreground(ErrorColor).
-			Bold(true)
+		Foreground(ErrorColor).
+		Bold(true)
 
 	WarningStyle = lipgloss.NewStyle().
-			Foreground(WarningColor).
-			Bold(true)
+		Foreground(WarningColor).
+		Bold(true)
 
 	InfoStyle = lipgloss.NewStyle().
-			Foreground(InfoColor).
-			Bold(true)
+		Foreground(InfoColor).
+		Bold(true)
 
 	MutedStyle = lipgloss.NewStyle().
-			Foreground(MutedColor)
+		Foreground(MutedColor)
 
 	TitleStyle = lipgloss.NewStyle().
-			Foreground(PrimaryColor).
-			Bold(true).
-			Padding(0, 1)
+		Foreground(PrimaryColor).
+		Bold(true).
+		Padding(0, 1)
 
 	SubtitleStyle = lipgloss.NewStyle().
-			Foreground(SecondaryColor).
-			Italic(true)
-)
+		Foreground(SecondaryColor).
+		Italic(true)
+}
+
+var quiet bool
+
+func SetQuiet(q bool) {
+	quiet = q
+}
 
 func PrintSuccess(msg string) {
+	if quiet {
+		return
+	}
 	fmt.Println(SuccessStyle.Render("✓ " + msg))
 }
 
 func PrintError(msg string) {
-	fmt.Println(ErrorStyle.Render("✗ " + msg))
+	if quiet {
+		return
+	}
+	fmt.Fprintln(os.Stderr, ErrorStyle.Render("✗ "+msg))
 }
 
 func PrintWarning(msg string) {
-	fmt.Println(WarningStyle.Render("⚠ " + msg))
+	if quiet {
+		return
+	}
+	fmt.Fprintln(os.Stderr, WarningStyle.Render("⚠ "+msg))
 }
 
 func PrintInfo(msg string) {
+	if quiet {
+		return
+	}
 	fmt.Println(InfoStyle.Render("ℹ " + msg))
 }
+
+// PrintDetail prints a plain line that belongs to the message before it, such
+// as the projects a bulk change touched. Like the messages, it is silenced by
+// quiet.
+func PrintDetail(msg string) {
+	if quiet {
+		return
+	}
+	fmt.Println(msg)
+}
+
+func FormatSize(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// FormatDuration rounds d to whole minutes, hours or days.
+func FormatDuration(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return "<1m"
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	case d < 24*time.Hour:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	}
+	return fmt.Sprintf("%dd", int(d.Hours()/24))
+}
+
+// FormatHours writes tracked time to the minute, such as "45m" or "3h 05m".
+func FormatHours(d time.Duration) string {
+	if d < time.Minute {
+		return "<1m"
+	}
+	h, m := int(d.Hours()), int(d.Minutes())%60
+	if h == 0 {
+		return fmt.Sprintf("%dm", m)
+	}
+	return fmt.Sprintf("%dh %02dm", h, m)
+}
+
+func ParseDuration(s string) (time.Duration, error) {
+	if s == "" {
+		return 0, nil
+	}
+
+	units := map[byte]time.Duration{
+		'd': 24 * time.Hour,
+		'w': 7 * 24 * time.Hour,
+	}
+	if unit, ok := units[s[len(s)-1]]; ok {
+		n, err := strconv.Atoi(s[:len(s)-1])
+		if err != nil {
+			return 0, fmt.Errorf("invalid duration '%s'", s)
+		}
+		return time.Duration(n) * unit, nil
+	}
+
+	return time.ParseDuration(s)
+}
+
+// OpenURL opens url in the default browser without waiting for it.
+func OpenURL(url string) error {
+	var cmd *exec.Cmd
+	switch runtime.GOOS {
+	case "darwin":
+		cmd = exec.Command("open", url)
+	case "windows":
+		cmd = exec.Command("rundll32", "url.dll,FileProtocolHandler", url)
+	default:
+		cmd = exec.Command("xdg-open", url)
+	}
+	if err := cmd.Start(); err != nil {
+		return err
+	}
+	go cmd.Wait()
+	return nil
+}
+
+// ExecShell replaces the process with the user's shell, started in the
+// current directory. It only returns if the shell cannot be started.
+func ExecShell() error {
+	shell := os.Getenv("SHELL")
+	if shell == "" {
+		shell = "/bin/bash"
+	}
+	return syscall.Exec(shell, []string{shell}, os.Environ())
+}